@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/gomodule"
+	"github.com/nsevendev/starter/internal/projets/openapi"
+	"github.com/nsevendev/starter/internal/tools"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	apiGenerateSpec   string
+	apiGenerateName   string
+	apiGenerateNoWire bool
+)
+
+// apiNamePattern nom du controller généré, utilisé comme nom de package Go
+var apiNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "outils pour l'api go d'un projet stage2",
+}
+
+var apiGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "génère les types, l'interface serveur et les handlers de l'api go à partir d'une spec OpenAPI 3",
+	Long: `Génère dans internal/application/controller/<nom>controller de l'api go d'un projet stage2:
+	- Models.gen.go: types des schémas, paramètres, corps de requête et réponses (tags json et binding)
+	- Server.gen.go: ServerInterface, RegisterHandlers(httpgateway.Router, ServerInterface) et la lecture
+	  des paramètres path/query/header et du corps JSON (400 si invalide, 422 si la validation binding échoue)
+	- Controller.go et un fichier par opération (<OperationId>.go): handlers à implémenter
+Les opérations avec security (celle de l'opération ou du document) sont réservées aux utilisateurs
+authentifiés (api créée avec --auth), les scopes de la sécurité sont les permissions requises.
+Les fichiers .gen.go sont réécrits à chaque génération, les handlers existants ne sont jamais modifiés:
+seuls les handlers des nouvelles opérations sont créés, les signatures à mettre à jour et les handlers
+d'opérations supprimées de la spec sont signalés.
+Le controller est câblé dans getControllers() de cmd/api/main.go (sauf avec --no-wire).
+À lancer à la racine du projet ou dans le dossier api.`,
+	Example: `  starter api generate --spec openapi.yaml
+  starter api generate --spec openapi.yaml --name petstore`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if apiGenerateSpec == "" {
+			return fmt.Errorf("--spec obligatoire")
+		}
+		pkg := apiGenerateName + "controller"
+		if !apiNamePattern.MatchString(apiGenerateName) {
+			return fmt.Errorf("nom invalide %q: lettres minuscules et chiffres uniquement", apiGenerateName)
+		}
+
+		doc, err := openapi.Load(apiGenerateSpec)
+		if err != nil {
+			return err
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("erreur récupération du path du dossier courant: %v", err)
+		}
+		pathApi, err := findApiRoot(cwd)
+		if err != nil {
+			return err
+		}
+		moduleName, err := gomodule.ModulePath(filepath.Join(pathApi, "go.mod"))
+		if err != nil {
+			return err
+		}
+
+		api, err := openapi.Build(doc, moduleName, pkg)
+		if err != nil {
+			return fmt.Errorf("spec %s: %w", apiGenerateSpec, err)
+		}
+
+		fmt.Printf("------ Génération de l'api %s (%d opérations) ------\n", pkg, len(api.Endpoints))
+		// les opérations avec security sont vérifiées par l'authentification JWT de l'api (--auth)
+		if api.Secured() {
+			if _, err := os.Stat(filepath.Join(pathApi, "internal", "application", "middleware", "authmiddleware")); err != nil {
+				fmt.Println("- [WARN] opérations avec security: api sans authmiddleware (créée sans --auth), ces routes répondront 401 -")
+			}
+		}
+		if err := generateApi(pathApi, api); err != nil {
+			return err
+		}
+
+		registration := openapi.Registration(api)
+		if apiGenerateNoWire {
+			printManualWiring(registration)
+			return nil
+		}
+		return wireMain(pathApi, registration)
+	},
+}
+
+func generateApi(pathApi string, api *openapi.Api) error {
+	pathControllerDir := filepath.Join(pathApi, "internal", "application", "controller", api.Package)
+	if err := tools.EnsureDir(pathControllerDir); err != nil {
+		return fmt.Errorf("- [KO] création du dossier %s: %v", pathControllerDir, err)
+	}
+	rel := func(name string) string {
+		r, _ := filepath.Rel(pathApi, filepath.Join(pathControllerDir, name))
+		return r
+	}
+
+	// fichiers générés, toujours réécrits
+	for _, f := range []struct {
+		name    string
+		content string
+	}{
+		{"Models.gen.go", openapi.ModelsContent(api)},
+		{"Server.gen.go", openapi.ServerContent(api)},
+	} {
+		if err := tools.WriteFileAlways(filepath.Join(pathControllerDir, f.name), f.content); err != nil {
+			return fmt.Errorf("- [KO] écriture %s: %v", rel(f.name), err)
+		}
+		fmt.Printf("- [OK] écriture %s -\n", rel(f.name))
+	}
+
+	// handlers écrits à la main, créés seulement s'ils n'existent pas
+	stubs := []struct {
+		name    string
+		content string
+	}{
+		{"Controller.go", openapi.ControllerContent(api)},
+	}
+	for _, op := range api.Endpoints {
+		stubs = append(stubs, struct {
+			name    string
+			content string
+		}{op.Name + ".go", openapi.HandlerContent(api, op)})
+	}
+	for _, f := range stubs {
+		path := filepath.Join(pathControllerDir, f.name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := tools.WriteFileIfAbsent(path, f.content); err != nil {
+			return fmt.Errorf("- [KO] création %s: %v", rel(f.name), err)
+		}
+		fmt.Printf("- [OK] création %s -\n", rel(f.name))
+	}
+
+	issues, err := openapi.CheckHandlers(pathControllerDir, api)
+	if err != nil {
+		return fmt.Errorf("- [KO] vérification des handlers: %v", err)
+	}
+	for _, issue := range issues {
+		fmt.Printf("- [WARN] %s: %s %s -\n", rel(issue.File), issue.Method, issue.Message)
+	}
+
+	fmt.Printf("------ Api %s générée ------\n", api.Package)
+	return nil
+}
+
+func init() {
+	apiGenerateCmd.Flags().StringVar(&apiGenerateSpec, "spec", "", "spec OpenAPI 3 (YAML ou JSON)")
+	apiGenerateCmd.Flags().StringVar(&apiGenerateName, "name", "api", "nom du controller généré (<nom>controller)")
+	apiGenerateCmd.Flags().BoolVar(&apiGenerateNoWire, "no-wire", false, "ne modifie pas cmd/api/main.go (affiche le câblage à faire)")
+	apiCmd.AddCommand(apiGenerateCmd)
+	rootCmd.AddCommand(apiCmd)
+}
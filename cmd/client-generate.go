@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/projets/client"
+	"github.com/nsevendev/starter/internal/tools"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+var (
+	clientGenerateSwagger string
+	clientGenerateOut     string
+)
+
+var clientCmd = &cobra.Command{
+	Use:   "client",
+	Short: "outils pour le front d'un projet stage2",
+}
+
+var clientGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "génère un client TypeScript typé pour le front à partir du swagger de l'api go",
+	Long: `Génère un client TypeScript typé à partir de api/docs/swagger.json (généré par swag au lancement de l'api):
+	- types.ts: types des définitions (DTO de requête et de réponse)
+	- client.ts: une fonction fetch typée par route, ApiError pour les réponses hors 2xx
+	- config.ts: url de base de l'api, PUBLIC_API_URL du .env du front
+Les fichiers sont écrits dans front/src/lib/api (Astro) ou front/src/app/services/api (Angular)
+et réécrits à chaque génération.
+À lancer à la racine du projet, dans le dossier api ou dans le dossier front.`,
+	Example: `  starter client generate
+  starter client generate --swagger api/docs/swagger.json --out front/src/lib/api`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("erreur récupération du path du dossier courant: %v", err)
+		}
+		pathProject, err := findProjectRoot(cwd)
+		if err != nil {
+			return err
+		}
+		pathFront := filepath.Join(pathProject, nameServiceFront)
+
+		pathSwagger := clientGenerateSwagger
+		if pathSwagger == "" {
+			pathSwagger = filepath.Join(pathProject, nameServiceApi, "docs", "swagger.json")
+		}
+		doc, err := client.Load(pathSwagger)
+		if err != nil {
+			return fmt.Errorf("%w (lancez l'api ou swag init pour générer le swagger)", err)
+		}
+		c, err := client.Build(doc)
+		if err != nil {
+			return fmt.Errorf("swagger %s: %w", pathSwagger, err)
+		}
+
+		framework := "astro"
+		pathOut := filepath.Join(pathFront, "src", "lib", "api")
+		if _, err := os.Stat(filepath.Join(pathFront, "angular.json")); err == nil {
+			framework = "angular"
+			pathOut = filepath.Join(pathFront, "src", "app", "services", "api")
+		}
+		if clientGenerateOut != "" {
+			pathOut = clientGenerateOut
+		}
+
+		baseURL, ok, err := tools.ReadEnvValue(filepath.Join(pathFront, ".env"), "PUBLIC_API_URL")
+		if err != nil || !ok {
+			fmt.Println("- [WARN] PUBLIC_API_URL absent de front/.env, ajoutez l'url de l'api (ex: PUBLIC_API_URL=https://api.localhost/api/v1) -")
+		}
+
+		fmt.Printf("------ Génération du client %s (%d routes, %d types) ------\n", framework, len(c.Functions), len(c.Types))
+		if err := tools.EnsureDir(pathOut); err != nil {
+			return fmt.Errorf("- [KO] création du dossier %s: %v", pathOut, err)
+		}
+		for _, f := range []struct {
+			name    string
+			content string
+		}{
+			{"types.ts", client.TypesContent(c)},
+			{"client.ts", client.ClientContent(c)},
+			{"config.ts", client.ConfigContent(framework, baseURL)},
+		} {
+			rel, _ := filepath.Rel(pathProject, filepath.Join(pathOut, f.name))
+			if err := tools.WriteFileAlways(filepath.Join(pathOut, f.name), f.content); err != nil {
+				return fmt.Errorf("- [KO] écriture %s: %v", rel, err)
+			}
+			fmt.Printf("- [OK] écriture %s -\n", rel)
+		}
+
+		fmt.Println("------ Client généré ------")
+		return nil
+	},
+}
+
+// findProjectRoot remonte depuis dir jusqu'à trouver un projet stage2 (dossiers api et front)
+func findProjectRoot(dir string) (string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		_, errApi := os.Stat(filepath.Join(current, nameServiceApi, "go.mod"))
+		_, errFront := os.Stat(filepath.Join(current, nameServiceFront))
+		if errApi == nil && errFront == nil {
+			return current, nil
+		}
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("aucun projet stage2 trouvé depuis %s (dossiers %s et %s attendus)", dir, nameServiceApi, nameServiceFront)
+		}
+	}
+}
+
+func init() {
+	clientGenerateCmd.Flags().StringVar(&clientGenerateSwagger, "swagger", "", "document Swagger de l'api (défaut: api/docs/swagger.json)")
+	clientGenerateCmd.Flags().StringVar(&clientGenerateOut, "out", "", "dossier du client généré (défaut: front/src/lib/api ou front/src/app/services/api)")
+	clientCmd.AddCommand(clientGenerateCmd)
+	rootCmd.AddCommand(clientCmd)
+}
@@ -1,12 +1,14 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"github.com/nsevendev/starter/internal/config"
+	"github.com/nsevendev/starter/internal/gomodule"
+	"github.com/nsevendev/starter/internal/jsonpatch"
+	"github.com/nsevendev/starter/internal/templates"
 	"github.com/nsevendev/starter/internal/tools"
 	"github.com/spf13/cobra"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 )
@@ -15,6 +17,10 @@ var (
 	initProjectName string
 	initVersion     string
 	initHostTraefik string
+	initSource      string
+	initOffline     bool
+	initPin         bool
+	initTemplate    string
 )
 
 var initTempAngssrGo = &cobra.Command{
@@ -27,7 +33,12 @@ var initTempAngssrGo = &cobra.Command{
 			return fmt.Errorf("le flag --name est requis")
 		}
 		if initVersion == "" {
-			return fmt.Errorf("le flag --version est requis (ex: --version=v1.0.0)")
+			return fmt.Errorf("le flag --version est requis (ex: --version=v1.0.0), voir: starter templates versions %s", initTemplate)
+		}
+
+		// les pins sont indexés par template: un pin pris sur une autre source ferait échouer les récupérations depuis le catalogue
+		if initPin && initSource != "" {
+			return fmt.Errorf("--pin ne peut pas être utilisé avec --source")
 		}
 
 		// chemin du projet (dossier courant + nom du projet)
@@ -42,21 +53,46 @@ var initTempAngssrGo = &cobra.Command{
 			return fmt.Errorf("le dossier %s existe déjà", initProjectName)
 		}
 
-		// clone du repo avec la version specifiée
-		fmt.Printf("Clonage du template (version %s)...\n", initVersion)
-		cloneCmd := exec.Command("git", "clone", "--branch", initVersion, "--depth", "1",
-			"https://github.com/nsevendev/temp-angssr-go.git", initProjectName)
-		cloneCmd.Stdout = os.Stdout
-		cloneCmd.Stderr = os.Stderr
-		if err := cloneCmd.Run(); err != nil {
-			return fmt.Errorf("erreur lors du clonage: %w", err)
+		// récupération du template (cache local, vérification du pin)
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		tpl, err := cfg.Lookup(initTemplate)
+		if err != nil {
+			return err
+		}
+		// le pin du catalogue ne concerne que la source du catalogue
+		source, pin := initSource, (*config.Pin)(nil)
+		if source == "" {
+			source, pin = tpl.Source, cfg.Pin(initTemplate, initVersion)
 		}
 
-		// delete old .git
-		fmt.Println("Suppression du .git...")
-		gitPath := filepath.Join(projectPath, ".git")
-		if err := os.RemoveAll(gitPath); err != nil {
-			return fmt.Errorf("erreur lors de la suppression du .git: %w", err)
+		fmt.Printf("Récupération du template (version %s)...\n", initVersion)
+		snap, err := templates.Fetch(templates.FetchOptions{
+			Name:    initTemplate,
+			Source:  source,
+			Ref:     initVersion,
+			Offline: initOffline,
+			Pin:     pin,
+		})
+		if err != nil {
+			return fmt.Errorf("erreur lors de la récupération du template: %w", err)
+		}
+
+		// épinglage du ref sur le contenu récupéré
+		if initPin {
+			cfg.SetPin(initTemplate, initVersion, config.Pin{Commit: snap.Commit, Sha256: snap.Sha256})
+			if err := cfg.Save(); err != nil {
+				return err
+			}
+			fmt.Printf("    ✓ %s@%s épinglé (commit: %s, sha256: %s)\n", initTemplate, initVersion, snap.Commit, snap.Sha256)
+		}
+
+		// copie du template sans son .git
+		fmt.Println("Création du projet depuis le template...")
+		if err := templates.Instantiate(snap, projectPath); err != nil {
+			return err
 		}
 
 		// Application des modifications automatiques
@@ -74,6 +110,10 @@ func init() {
 	initTempAngssrGo.Flags().StringVar(&initProjectName, "name", "", "nom du projet (requis)")
 	initTempAngssrGo.Flags().StringVar(&initVersion, "version", "", "version du template (ex: v1.0.0) (requis)")
 	initTempAngssrGo.Flags().StringVar(&initHostTraefik, "hostTraefik", "", "host pour Traefik (ex: myproject.local)")
+	initTempAngssrGo.Flags().StringVar(&initTemplate, "template", config.DefaultTemplateName, "nom du template dans le catalogue (voir: starter templates list)")
+	initTempAngssrGo.Flags().StringVar(&initSource, "source", "", "source du template: url git, archive .tar.gz (url ou chemin) ou dossier local")
+	initTempAngssrGo.Flags().BoolVar(&initOffline, "offline", false, "utilise uniquement le cache local des templates")
+	initTempAngssrGo.Flags().BoolVar(&initPin, "pin", false, "épingle la version sur le commit et le checksum récupérés (config)")
 
 	rootCmd.AddCommand(initTempAngssrGo)
 }
@@ -141,7 +181,7 @@ func applyTemplateModifications(projectPath string) error {
 		return err
 	}
 
-	// 11. Remplacement des imports "temp-angssr-go" par le nom du projet dans tous les fichiers Go de api/
+	// 11. Renommage du module Go de api/ (go.mod + imports) avec le nom du projet
 	if err := replaceGoImports(projectPath, initProjectName); err != nil {
 		return err
 	}
@@ -154,57 +194,34 @@ func modifyAngularJson(projectPath, allowedHost string) error {
 	fmt.Println("  Modification de app/angular.json...")
 	filePath := filepath.Join(projectPath, "app", "angular.json")
 
-	content, err := os.ReadFile(filePath)
+	doc, err := jsonpatch.ParseFile(filePath)
 	if err != nil {
 		return fmt.Errorf("lecture de angular.json: %w", err)
 	}
 
-	var angularConfig map[string]interface{}
-	if err := json.Unmarshal(content, &angularConfig); err != nil {
-		return fmt.Errorf("parsing de angular.json: %w", err)
-	}
-
 	// Navigation dans la structure JSON pour trouver allowedHosts
-	projects, ok := angularConfig["projects"].(map[string]interface{})
-	if !ok {
+	projects, err := doc.Keys("/projects")
+	if err != nil {
 		return fmt.Errorf("structure projects non trouvée dans angular.json")
 	}
 
 	// Trouver le premier projet (généralement le nom du projet)
-	for projectKey, projectVal := range projects {
-		projectData, ok := projectVal.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		architect, ok := projectData["architect"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		serve, ok := architect["serve"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		options, ok := serve["options"].(map[string]interface{})
-		if !ok {
+	for _, projectKey := range projects {
+		options := jsonpatch.Pointer("projects", projectKey, "architect", "serve", "options")
+		if _, err := doc.Keys(options); err != nil {
 			continue
 		}
 
 		// Mise à jour de allowedHosts avec un slice contenant le host
-		options["allowedHosts"] = []string{allowedHost}
+		if err := doc.Apply(jsonpatch.Add(options+jsonpatch.Pointer("allowedHosts"), []string{allowedHost})); err != nil {
+			return fmt.Errorf("modification de angular.json: %w", err)
+		}
 		fmt.Printf("    ✓ allowedHosts configuré pour le projet '%s': [%s]\n", projectKey, allowedHost)
 		break
 	}
 
-	// Réécriture du fichier JSON
-	newContent, err := json.MarshalIndent(angularConfig, "", "  ")
-	if err != nil {
-		return fmt.Errorf("serialization de angular.json: %w", err)
-	}
-
-	if err := os.WriteFile(filePath, newContent, 0o644); err != nil {
+	// Réécriture du fichier JSON (ordre des clés et indentation conservés)
+	if err := doc.WriteFile(filePath); err != nil {
 		return fmt.Errorf("écriture de angular.json: %w", err)
 	}
 
@@ -472,9 +489,9 @@ func copyAppEnv(projectPath string) error {
 	return nil
 }
 
-// replaceGoImports remplace les imports "temp-angssr-go" par le nom du projet dans tous les fichiers Go de api/
+// replaceGoImports renomme le module Go de api/ (go.mod + imports) avec le nom du projet
 func replaceGoImports(projectPath, projectName string) error {
-	fmt.Println("  Remplacement des imports Go dans api/...")
+	fmt.Println("  Renommage du module Go dans api/...")
 	apiPath := filepath.Join(projectPath, "api")
 
 	// Vérifier que le dossier api/ existe
@@ -483,19 +500,14 @@ func replaceGoImports(projectPath, projectName string) error {
 		return nil
 	}
 
-	// 1. Modifier le go.mod dans api/
-	goModPath := filepath.Join(apiPath, "go.mod")
-	if _, err := os.Stat(goModPath); err == nil {
-		if err := tools.ReplaceInFile(goModPath, "temp-angssr-go/api", projectName+"/api"); err != nil {
-			return fmt.Errorf("modification de go.mod: %w", err)
-		}
+	report, err := gomodule.RenameModule(apiPath, projectName+"/api")
+	if err != nil {
+		return fmt.Errorf("renommage du module Go: %w", err)
 	}
 
-	// 2. Remplacer "temp-angssr-go" par le nom du projet dans tous les fichiers .go
-	if err := tools.ReplaceInAllGoFiles(apiPath, "temp-angssr-go", projectName); err != nil {
-		return fmt.Errorf("remplacement des imports Go: %w", err)
+	for _, file := range report.FilesModified {
+		fmt.Printf("  ✓ %s modifié\n", file)
 	}
-
-	fmt.Println("    ✓ Imports Go mis à jour")
+	fmt.Printf("    ✓ Module %s renommé en %s (%d fichier(s) Go modifié(s))\n", report.OldModule, report.NewModule, len(report.FilesModified))
 	return nil
 }
@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/gomodule"
+	"github.com/nsevendev/starter/internal/projets/resource"
+	"github.com/nsevendev/starter/internal/projets/stage2"
+	"github.com/nsevendev/starter/internal/tools"
+	"github.com/nsevendev/starter/internal/wiring"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	makeResourceNoWire bool
+	makeResourceSpec   string
+)
+
+var makeResourceCmd = &cobra.Command{
+	Use:   "make:resource [Nom]",
+	Short: "génère une ressource CRUD (domain, repository, use case, controller) dans l'api go d'un projet stage2",
+	Long: `Génère une ressource CRUD complète dans l'api go d'un projet stage2, sur le modèle de la ressource nseven:
+	- internal/domain/<nom>: entité + interface du repository + schéma de la collection (index et validateur, MongoDB)
+	- internal/infrastructure/repository/<nom>repository: repository MongoDB, PostgreSQL ou SQLite selon le --db de l'api
+	- migrations/<numéro>_create_<table>.up.sql et .down.sql (PostgreSQL et SQLite): table de la ressource
+	- internal/application/usecase/<nom>usecase: use case
+	- internal/application/controller/<nom>controller: controller (create, get all, get, update, delete)
+Sans --spec l'entité a un seul champ name (string, obligatoire).
+Avec --spec, l'entité est décrite en YAML (champs, types, obligatoire, unique, règles de validation, valeurs par défaut):
+	name: Product
+	fields:
+	  - name: title
+	    type: string
+	    required: true
+	    unique: true
+	    validate: min=3,max=120
+	  - name: stock
+	    type: int
+	    default: 0
+	indexes:
+	  - fields: [stock, -createdAt]
+	access:
+	  read: [user, admin]
+	  write: [admin]
+access (api créée avec --auth) réserve les routes GET (read) et POST, PUT, DELETE (write) aux rôles listés,
+ou à tous les utilisateurs authentifiés avec authenticated: true.
+En PostgreSQL et SQLite les colonnes portent le nom json des champs, les types liste ([]string, []int, []float64)
+et les index text ou ttl ne sont pas supportés (MongoDB uniquement).
+Le controller est câblé dans getControllers() de cmd/api/main.go (sauf avec --no-wire).
+À lancer à la racine du projet ou dans le dossier api.`,
+	Example: `  starter make:resource BlogPost
+  starter make:resource --spec product.yaml`,
+	Args: cobra.RangeArgs(0, 1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var res *resource.Resource
+		var err error
+		switch {
+		case makeResourceSpec != "":
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			res, err = resource.LoadSpec(makeResourceSpec, name)
+		case len(args) == 1:
+			res, err = resource.New(args[0])
+		default:
+			return fmt.Errorf("nom de la ressource ou --spec obligatoire")
+		}
+		if err != nil {
+			return err
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("erreur récupération du path du dossier courant: %v", err)
+		}
+		pathApi, err := findApiRoot(cwd)
+		if err != nil {
+			return err
+		}
+		moduleName, err := gomodule.ModulePath(filepath.Join(pathApi, "go.mod"))
+		if err != nil {
+			return err
+		}
+		db, err := apiDb(pathApi)
+		if err != nil {
+			return err
+		}
+		if db.Sql() {
+			if err := res.SqlSupported(); err != nil {
+				return fmt.Errorf("api %s: %w", db.Label(), err)
+			}
+		}
+		// les routes protégées sont vérifiées par l'authentification JWT de l'api (--auth)
+		if res.Protected() {
+			if _, err := os.Stat(filepath.Join(pathApi, "internal", "application", "middleware", "authmiddleware")); err != nil {
+				return fmt.Errorf("access dans la spec: api sans authmiddleware (créée sans --auth) non supportée")
+			}
+		}
+
+		fmt.Printf("------ Création de la ressource %s (module %s, %s) ------\n", res.Name, moduleName, db.Label())
+		if err := createResource(pathApi, moduleName, res, db); err != nil {
+			return err
+		}
+
+		registration := resource.Registration(moduleName, res, db)
+		if makeResourceNoWire {
+			printManualWiring(registration)
+			return nil
+		}
+		return wireMain(pathApi, registration)
+	},
+}
+
+// findApiRoot remonte depuis dir jusqu'à trouver l'api go d'un projet stage2 (api/go.mod ou go.mod + cmd/api/main.go)
+func findApiRoot(dir string) (string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		if isApiRoot(filepath.Join(current, nameServiceApi)) {
+			return filepath.Join(current, nameServiceApi), nil
+		}
+		if isApiRoot(current) {
+			return current, nil
+		}
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("aucune api go trouvée depuis %s (go.mod et cmd/api/main.go attendus)", dir)
+		}
+	}
+}
+
+func isApiRoot(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err != nil {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, "cmd", "api", "main.go"))
+	return err == nil
+}
+
+// apiDb base de données de l'api d'après l'adapter et le driver de son go.mod (--db du projet stage2)
+func apiDb(pathApi string) (stage2.Db, error) {
+	if _, err := os.Stat(filepath.Join(pathApi, "internal", "infrastructure", "adapter", "sqladapter")); err != nil {
+		return stage2.DbMongo, nil
+	}
+	goMod, err := os.ReadFile(filepath.Join(pathApi, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("erreur lecture du go.mod de l'api: %v", err)
+	}
+	for _, db := range []stage2.Db{stage2.DbPostgres, stage2.DbSqlite} {
+		if strings.Contains(string(goMod), db.Dependency()) {
+			return db, nil
+		}
+	}
+	return "", fmt.Errorf("api avec sqladapter sans driver %s ou %s dans go.mod", stage2.DbPostgres.Dependency(), stage2.DbSqlite.Dependency())
+}
+
+func createResource(pathApi, moduleName string, res *resource.Resource, db stage2.Db) error {
+	pathDomainDir := filepath.Join(pathApi, "internal", "domain", res.Package)
+	pathRepositoryDir := filepath.Join(pathApi, "internal", "infrastructure", "repository", res.Package+"repository")
+	pathUseCaseDir := filepath.Join(pathApi, "internal", "application", "usecase", res.Package+"usecase")
+	pathControllerDir := filepath.Join(pathApi, "internal", "application", "controller", res.Package+"controller")
+
+	// une ressource existante n'est jamais écrasée, même partiellement
+	if _, err := os.Stat(pathDomainDir); err == nil {
+		return fmt.Errorf("- [KO] la ressource %s existe déjà (%s)", res.Name, pathDomainDir)
+	}
+
+	type resourceFile struct {
+		dir     string
+		name    string
+		content string
+	}
+	files := []resourceFile{
+		{pathDomainDir, res.Name + ".go", resource.EntityContent(res)},
+		{pathDomainDir, res.Name + "RepositoryInterface.go", resource.RepositoryInterfaceContent(moduleName, res)},
+		{pathUseCaseDir, res.Name + "UseCase.go", resource.UseCaseContent(moduleName, res)},
+		{pathControllerDir, "Controller.go", resource.ControllerContent(moduleName, res)},
+		{pathControllerDir, "Dto.go", resource.DtoContent(moduleName, res)},
+		{pathControllerDir, "Create" + res.Name + ".go", resource.CreateContent(moduleName, res)},
+		{pathControllerDir, "GetAll" + res.Name + ".go", resource.GetAllContent(moduleName, res)},
+		{pathControllerDir, "Get" + res.Name + ".go", resource.GetContent(moduleName, res)},
+		{pathControllerDir, "Update" + res.Name + ".go", resource.UpdateContent(moduleName, res)},
+		{pathControllerDir, "Delete" + res.Name + ".go", resource.DeleteContent(moduleName, res)},
+	}
+	if db.Sql() {
+		// la table est créée par une migration numérotée après la dernière du dossier migrations
+		pathMigrationsDir := filepath.Join(pathApi, "migrations")
+		version, err := nextMigrationVersion(pathMigrationsDir)
+		if err != nil {
+			return fmt.Errorf("- [KO] lecture du dossier migrations: %v", err)
+		}
+		name := fmt.Sprintf("%04d_create_%s", version, res.Collection)
+		files = append(files, []resourceFile{
+			{pathRepositoryDir, "Sql" + res.Name + "Repository.go", resource.SqlRepositoryContent(moduleName, res, db)},
+			{pathMigrationsDir, name + ".up.sql", resource.SqlMigrationUpContent(res, db)},
+			{pathMigrationsDir, name + ".down.sql", resource.SqlMigrationDownContent(res)},
+		}...)
+	} else {
+		files = append(files, []resourceFile{
+			{pathDomainDir, "Schema.go", resource.SchemaContent(moduleName, res)},
+			{pathRepositoryDir, "Mongo" + res.Name + "Repository.go", resource.MongoRepositoryContent(moduleName, res)},
+		}...)
+	}
+
+	for _, f := range files {
+		rel, _ := filepath.Rel(pathApi, filepath.Join(f.dir, f.name))
+		if err := tools.EnsureDir(f.dir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier %s: %v", filepath.Dir(rel), err)
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(f.dir, f.name), f.content); err != nil {
+			return fmt.Errorf("- [KO] création %s: %v", rel, err)
+		}
+		fmt.Printf("- [OK] création %s -\n", rel)
+	}
+
+	fmt.Printf("------ Ressource %s créée ------\n", res.Name)
+	return nil
+}
+
+// nextMigrationVersion numéro de la prochaine migration SQL: dernier préfixe <numéro>_ du dossier + 1
+func nextMigrationVersion(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	last := 0
+	for _, entry := range entries {
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		if version, err := strconv.Atoi(prefix); err == nil && version > last {
+			last = version
+		}
+	}
+	return last + 1, nil
+}
+
+// wireMain câble une registration dans cmd/api/main.go
+func wireMain(pathApi string, registration wiring.Registration) error {
+	pathMainGo := filepath.Join(pathApi, "cmd", "api", "main.go")
+	report, err := wiring.WireControllers(pathMainGo, registration)
+	if err != nil {
+		printManualWiring(registration)
+		return fmt.Errorf("- [KO] câblage de cmd/api/main.go: %v", err)
+	}
+	if report.Empty() {
+		fmt.Println("- [OK] cmd/api/main.go déjà câblé -")
+		return nil
+	}
+	fmt.Println("- [OK] câblage de cmd/api/main.go -")
+	for _, imp := range report.Imports {
+		fmt.Printf("    + import %q\n", imp)
+	}
+	for _, stmt := range report.Statements {
+		fmt.Printf("    + %s\n", stmt)
+	}
+	for _, ctrl := range report.Controllers {
+		fmt.Printf("    + controller %s\n", ctrl)
+	}
+	return nil
+}
+
+func printManualWiring(registration wiring.Registration) {
+	fmt.Println("Pour l'activer, ajoutez dans cmd/api/main.go:")
+	for _, imp := range registration.Imports {
+		fmt.Printf("\timport %q\n", imp)
+	}
+	fmt.Println("puis dans getControllers():")
+	for _, stmt := range registration.Statements {
+		fmt.Printf("\t%s\n", stmt)
+	}
+	for _, ctrl := range registration.Controllers {
+		fmt.Printf("\t%s, // dans la liste des controllers\n", ctrl)
+	}
+}
+
+func init() {
+	makeResourceCmd.Flags().StringVar(&makeResourceSpec, "spec", "", "spec YAML de l'entité (champs, types, validation, valeurs par défaut)")
+	makeResourceCmd.Flags().BoolVar(&makeResourceNoWire, "no-wire", false, "ne modifie pas cmd/api/main.go (affiche le câblage à faire)")
+	rootCmd.AddCommand(makeResourceCmd)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/nsevendev/starter/internal/docker"
+	"github.com/nsevendev/starter/internal/packagejson"
 	"github.com/nsevendev/starter/internal/projets/framework"
 	"github.com/nsevendev/starter/internal/projets/stage1"
 	"github.com/nsevendev/starter/internal/tools"
@@ -284,19 +285,21 @@ var starter1Cmd = &cobra.Command{
 		// modification package.json
 		{
 			// package.json est dans le dossier de l'app
-			if err := stage1.ReplacePackageJSONScripts(filepath.Join(pathFolderApp, "package.json"), map[string]string{
-				"ng":            "ng",
-				"start":         "ng serve",
-				"build":         "ng build --configuration production",
-				"build:ssr":     "ng build --configuration production",
-				"watch":         "ng build --watch --configuration development",
-				"test":          "ng test --browsers=ChromeHeadlessNoSandbox --watch --poll=2000",
-				"test:ci":       "ng test --watch=false --browsers=ChromeHeadlessNoSandbox",
-				"serve:ssr:app": "node dist/app/server/server.mjs",
-			}); err != nil {
+			report, err := stage1.MergePackageJSONScripts(filepath.Join(pathFolderApp, "package.json"), []packagejson.Entry{
+				packagejson.Keep("ng", "ng"),
+				packagejson.Set("start", "ng serve"),
+				packagejson.Set("build", "ng build --configuration production"),
+				packagejson.Set("build:ssr", "ng build --configuration production"),
+				packagejson.Set("watch", "ng build --watch --configuration development"),
+				packagejson.Set("test", "ng test --browsers=ChromeHeadlessNoSandbox --watch --poll=2000"),
+				packagejson.Set("test:ci", "ng test --watch=false --browsers=ChromeHeadlessNoSandbox"),
+				packagejson.Keep("serve:ssr:app", "node dist/app/server/server.mjs"),
+			})
+			if err != nil {
 				return fmt.Errorf("échec de la modification package.json: %v", err)
 			}
 			fmt.Println("- [OK] Modifcation app/package.json -")
+			report.Print()
 		}
 
 		// installation et configuration Tailwind (postcss + styles.css)
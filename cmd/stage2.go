@@ -16,6 +16,12 @@ var (
 	err               error
 	hostTraefikFront  string
 	hostTraefikApi    string
+	dbStage2          string
+	cacheStage2       bool
+	mailerStage2      bool
+	storageStage2     bool
+	authStage2        bool
+	optionsStage2     stage2.Options
 	pathFolderProject string
 	nameFolderProject string
 	pathFolderFront   string
@@ -29,7 +35,7 @@ var (
 
 var starter2 = &cobra.Command{
 	Use:   "stage2",
-	Short: "Astro ssr + api go + mongodb => node version 22.19.0, go version 1.24.4, mongo version 7.0",
+	Short: "Astro ssr + api go + mongodb, postgresql ou sqlite => node version 22.19.0, go version 1.24.4, mongo version 7.0, postgres version 16",
 	Long: `Création d'application legere, site vitrine, site dynamique, blog, e-commerce, portfolio, 
 			ne convient pas pour les applications complexes.
 			répondez au question pour la creation du projet Astro avec les réponses suivantes:
@@ -37,6 +43,11 @@ var starter2 = &cobra.Command{
 				- installation des dépendances => choisissez "no"
 				- init git => choisissez "no"
 				Ne suivez pas les instructions d'astro pour l'installation des dépendances.
+			la base de données de l'api est choisie avec --db (mongo par défaut, postgres ou sqlite).
+			--cache ajoute un cache redis à l'api (gateway cache, adapter redis et service redis).
+			--mailer ajoute l'envoi de mails à l'api (gateway mailer, adapter smtp, formulaire de contact et mailpit en dev).
+			--storage ajoute le stockage de fichiers S3/R2 à l'api (gateway storage, adapter s3, upload de fichiers et minio en dev).
+			--auth ajoute l'authentification JWT à l'api (utilisateurs, inscription, connexion, renouvellement et middleware).
 			`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		pathFolderProject, err = os.Getwd()
@@ -46,6 +57,15 @@ var starter2 = &cobra.Command{
 		nameFolderProject = filepath.Base(pathFolderProject)
 		pathFolderFront = filepath.Join(pathFolderProject, nameServiceFront)
 
+		optionsStage2.Db, err = stage2.ParseDb(dbStage2)
+		if err != nil {
+			return err
+		}
+		optionsStage2.Cache = cacheStage2
+		optionsStage2.Mailer = mailerStage2
+		optionsStage2.Storage = storageStage2
+		optionsStage2.Auth = authStage2
+
 		if err = validateUserForStart(); err != nil {
 			return err
 		}
@@ -103,7 +123,7 @@ func createAndSetDocker() error {
 	// Créer api.dockerfile
 	{
 		pathApiDockerfile := filepath.Join(pathDockerDir, "api.dockerfile")
-		if err := tools.WriteFileIfAbsent(pathApiDockerfile, stage2.ApiDockerfileContent()); err != nil {
+		if err := tools.WriteFileIfAbsent(pathApiDockerfile, stage2.ApiDockerfileContent(optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création docker/api.dockerfile: %v", err)
 		} else {
 			fmt.Println("- [OK] création docker/api.dockerfile -")
@@ -113,7 +133,7 @@ func createAndSetDocker() error {
 	// Créer compose.yaml
 	{
 		pathComposeYaml := filepath.Join(pathDockerDir, "compose.yaml")
-		if err := tools.WriteFileIfAbsent(pathComposeYaml, stage2.ComposeYamlContent(nameFolderProject)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathComposeYaml, stage2.ComposeYamlContent(nameFolderProject, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création docker/compose.yaml: %v", err)
 		} else {
 			fmt.Println("- [OK] création docker/compose.yaml -")
@@ -123,7 +143,7 @@ func createAndSetDocker() error {
 	// Créer compose.preprod.yaml
 	{
 		pathComposePreprodYaml := filepath.Join(pathDockerDir, "compose.preprod.yaml")
-		if err := tools.WriteFileIfAbsent(pathComposePreprodYaml, stage2.ComposePreprodYamlContent(nameFolderProject)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathComposePreprodYaml, stage2.ComposePreprodYamlContent(nameFolderProject, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création docker/compose.preprod.yaml: %v", err)
 		} else {
 			fmt.Println("- [OK] création docker/compose.preprod.yaml -")
@@ -133,31 +153,45 @@ func createAndSetDocker() error {
 	// Créer compose.prod.yaml
 	{
 		pathComposeProdYaml := filepath.Join(pathDockerDir, "compose.prod.yaml")
-		if err := tools.WriteFileIfAbsent(pathComposeProdYaml, stage2.ComposeProdYamlContent(nameFolderProject)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathComposeProdYaml, stage2.ComposeProdYamlContent(nameFolderProject, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création docker/compose.prod.yaml: %v", err)
 		} else {
 			fmt.Println("- [OK] création docker/compose.prod.yaml -")
 		}
 	}
 
-	// Créer mongo-init/init-volume-db.js
+	// Créer le script de création des bases par environnement (sqlite: un fichier par base dans api/data)
 	{
-		pathMongoInitDir := filepath.Join(pathDockerDir, "mongo-init")
-		pathMongoInitJs := filepath.Join(pathMongoInitDir, "init-volume-db.js")
-		if err := tools.EnsureDir(pathMongoInitDir); err != nil {
-			return fmt.Errorf("- [KO] création du dossier mongo-init: %v", err)
-		}
-		if err := tools.WriteFileIfAbsent(pathMongoInitJs, stage2.MongoInitContent(nameFolderProject)); err != nil {
-			return fmt.Errorf("- [KO] création docker/mongo-init/init-volume-db.js: %v", err)
-		} else {
-			fmt.Println("- [OK] création docker/mongo-init/init-volume-db.js -")
+		switch optionsStage2.Db {
+		case stage2.DbMongo:
+			pathMongoInitDir := filepath.Join(pathDockerDir, "mongo-init")
+			pathMongoInitJs := filepath.Join(pathMongoInitDir, "init-volume-db.js")
+			if err := tools.EnsureDir(pathMongoInitDir); err != nil {
+				return fmt.Errorf("- [KO] création du dossier mongo-init: %v", err)
+			}
+			if err := tools.WriteFileIfAbsent(pathMongoInitJs, stage2.MongoInitContent(nameFolderProject)); err != nil {
+				return fmt.Errorf("- [KO] création docker/mongo-init/init-volume-db.js: %v", err)
+			} else {
+				fmt.Println("- [OK] création docker/mongo-init/init-volume-db.js -")
+			}
+		case stage2.DbPostgres:
+			pathPostgresInitDir := filepath.Join(pathDockerDir, "postgres-init")
+			pathPostgresInitSh := filepath.Join(pathPostgresInitDir, "init-databases.sh")
+			if err := tools.EnsureDir(pathPostgresInitDir); err != nil {
+				return fmt.Errorf("- [KO] création du dossier postgres-init: %v", err)
+			}
+			if err := tools.WriteFileIfAbsent(pathPostgresInitSh, stage2.PostgresInitContent(nameFolderProject)); err != nil {
+				return fmt.Errorf("- [KO] création docker/postgres-init/init-databases.sh: %v", err)
+			} else {
+				fmt.Println("- [OK] création docker/postgres-init/init-databases.sh -")
+			}
 		}
 	}
 
 	// Créer Makefile à la racine
 	{
 		pathMakefile := filepath.Join(pathFolderProject, "Makefile")
-		if err := tools.WriteFileIfAbsent(pathMakefile, stage2.MakefileContent(nameFolderProject)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathMakefile, stage2.MakefileContent(nameFolderProject, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création Makefile: %v", err)
 		} else {
 			fmt.Println("- [OK] création Makefile -")
@@ -167,7 +201,7 @@ func createAndSetDocker() error {
 	// Créer .env à la racine
 	{
 		pathEnvRoot := filepath.Join(pathFolderProject, ".env")
-		if err := tools.WriteFileIfAbsent(pathEnvRoot, stage2.EnvRootContent(hostTraefikFront, hostTraefikApi)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathEnvRoot, stage2.EnvRootContent(hostTraefikFront, hostTraefikApi, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création .env à la racine: %v", err)
 		} else {
 			fmt.Println("- [OK] création .env à la racine -")
@@ -177,7 +211,7 @@ func createAndSetDocker() error {
 	// Créer .env.dist à la racine
 	{
 		pathEnvDistRoot := filepath.Join(pathFolderProject, ".env.dist")
-		if err := tools.WriteFileIfAbsent(pathEnvDistRoot, stage2.EnvRootContent(hostTraefikFront, hostTraefikApi)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathEnvDistRoot, stage2.EnvRootContent(hostTraefikFront, hostTraefikApi, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création .env.dist à la racine: %v", err)
 		} else {
 			fmt.Println("- [OK] création .env.dist à la racine -")
@@ -187,7 +221,7 @@ func createAndSetDocker() error {
 	// Créer README à la racine
 	{
 		path := filepath.Join(pathFolderProject, "README.md")
-		if err := tools.WriteFileIfAbsent(path, stage2.ReadmeContent(nameFolderProject)); err != nil {
+		if err := tools.WriteFileIfAbsent(path, stage2.ReadmeContent(nameFolderProject, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création du README à la racine: %v", err)
 		} else {
 			fmt.Println("- [OK] création du README à la racine -")
@@ -307,11 +341,23 @@ func createAndSetApi() error {
 			"github.com/nsevenpack/ginresponse@v1.2.3",
 			"github.com/nsevenpack/logger/v2@v2.2.0",
 			"github.com/swaggo/swag",
-			"go.mongodb.org/mongo-driver",
+			optionsStage2.Db.Dependency(),
 			"github.com/swaggo/gin-swagger",
 			"github.com/swaggo/files",
 		}
 
+		if optionsStage2.Cache {
+			dependencies = append(dependencies, "github.com/redis/go-redis/v9")
+		}
+		if optionsStage2.Storage {
+			// version compatible go 1.24
+			dependencies = append(dependencies, "github.com/minio/minio-go/v7@v7.0.98")
+		}
+		if optionsStage2.Auth {
+			// golang.org/x/crypto (bcrypt) est déjà une dépendance de gin, ajoutée par go mod tidy
+			dependencies = append(dependencies, "github.com/golang-jwt/jwt/v5")
+		}
+
 		for _, dep := range dependencies {
 			cmd := exec.Command("go", "get", dep)
 			cmd.Dir = pathFolderApi
@@ -338,12 +384,12 @@ func createAndSetApi() error {
 	{
 		pathEnv := filepath.Join(pathFolderApi, ".env")
 		pathEnvDist := filepath.Join(pathFolderApi, ".env.dist")
-		if err := tools.WriteFileIfAbsent(pathEnv, stage2.EnvApiContent(nameFolderProject, hostTraefikApi, hostTraefikFront)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathEnv, stage2.EnvApiContent(nameFolderProject, hostTraefikApi, hostTraefikFront, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création /api/.env: %v", err)
 		} else {
 			fmt.Println("- [OK] création /api/.env -")
 		}
-		if err := tools.WriteFileIfAbsent(pathEnvDist, stage2.EnvApiContent(nameFolderProject, hostTraefikApi, hostTraefikFront)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathEnvDist, stage2.EnvApiContent(nameFolderProject, hostTraefikApi, hostTraefikFront, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création /api/.env.dist: %v", err)
 		} else {
 			fmt.Println("- [OK] création /api/.env.dist -")
@@ -353,7 +399,7 @@ func createAndSetApi() error {
 	// Créer .gitignore
 	{
 		pathGitignore := filepath.Join(pathFolderApi, ".gitignore")
-		if err := tools.WriteFileIfAbsent(pathGitignore, stage2.GitignoreApiContent()); err != nil {
+		if err := tools.WriteFileIfAbsent(pathGitignore, stage2.GitignoreApiContent(optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création .gitignore: %v", err)
 		} else {
 			fmt.Println("- [OK] création .gitignore -")
@@ -409,13 +455,26 @@ func createAndSetApi() error {
 		if err := tools.EnsureDir(pathCmdApiDir); err != nil {
 			return fmt.Errorf("- [KO] création du dossier cmd/api: %v", err)
 		}
-		if err := tools.WriteFileIfAbsent(pathMainGo, stage2.MainGoContent(moduleName)); err != nil {
+		if err := tools.WriteFileIfAbsent(pathMainGo, stage2.MainGoContent(moduleName, optionsStage2)); err != nil {
 			return fmt.Errorf("- [KO] création cmd/api/main.go: %v", err)
 		} else {
 			fmt.Println("- [OK] création cmd/api/main.go -")
 		}
 	}
 
+	// Créer cmd/migrate/main.go (make migrate-up, migrate-down, migrate-status, migrate-create)
+	{
+		pathCmdMigrateDir := filepath.Join(pathFolderApi, "cmd", "migrate")
+		if err := tools.EnsureDir(pathCmdMigrateDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier cmd/migrate: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathCmdMigrateDir, "main.go"), stage2.MigrateCmdContent(moduleName, optionsStage2.Db)); err != nil {
+			return fmt.Errorf("- [KO] création cmd/migrate/main.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création cmd/migrate/main.go -")
+		}
+	}
+
 	// Créer infrastructure adapters
 	{
 		// GinAdapter
@@ -442,16 +501,101 @@ func createAndSetApi() error {
 			fmt.Println("- [OK] création LoggerAdapter.go -")
 		}
 
-		// MongoAdapter
-		pathMongoAdapterDir := filepath.Join(pathFolderApi, "internal", "infrastructure", "adapter", "mongoadapter")
-		pathMongoAdapter := filepath.Join(pathMongoAdapterDir, "MongoAdapter.go")
-		if err := tools.EnsureDir(pathMongoAdapterDir); err != nil {
-			return fmt.Errorf("- [KO] création du dossier mongoadapter: %v", err)
+		// MongoAdapter ou SqlAdapter
+		if optionsStage2.Db.Sql() {
+			pathSqlAdapterDir := filepath.Join(pathFolderApi, "internal", "infrastructure", "adapter", "sqladapter")
+			pathSqlAdapter := filepath.Join(pathSqlAdapterDir, "SqlAdapter.go")
+			if err := tools.EnsureDir(pathSqlAdapterDir); err != nil {
+				return fmt.Errorf("- [KO] création du dossier sqladapter: %v", err)
+			}
+			if err := tools.WriteFileIfAbsent(pathSqlAdapter, stage2.SqlAdapterContent(moduleName, optionsStage2.Db)); err != nil {
+				return fmt.Errorf("- [KO] création SqlAdapter.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création SqlAdapter.go -")
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathSqlAdapterDir, "Page.go"), stage2.SqlPageContent(moduleName, optionsStage2.Db)); err != nil {
+				return fmt.Errorf("- [KO] création sqladapter/Page.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création sqladapter/Page.go -")
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathSqlAdapterDir, "Migrate.go"), stage2.SqlMigrateContent(moduleName, optionsStage2.Db)); err != nil {
+				return fmt.Errorf("- [KO] création sqladapter/Migrate.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création sqladapter/Migrate.go -")
+			}
+		} else {
+			pathMongoAdapterDir := filepath.Join(pathFolderApi, "internal", "infrastructure", "adapter", "mongoadapter")
+			pathMongoAdapter := filepath.Join(pathMongoAdapterDir, "MongoAdapter.go")
+			if err := tools.EnsureDir(pathMongoAdapterDir); err != nil {
+				return fmt.Errorf("- [KO] création du dossier mongoadapter: %v", err)
+			}
+			if err := tools.WriteFileIfAbsent(pathMongoAdapter, stage2.MongoAdapterContent(moduleName)); err != nil {
+				return fmt.Errorf("- [KO] création MongoAdapter.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création MongoAdapter.go -")
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathMongoAdapterDir, "Page.go"), stage2.MongoPageContent(moduleName)); err != nil {
+				return fmt.Errorf("- [KO] création mongoadapter/Page.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création mongoadapter/Page.go -")
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathMongoAdapterDir, "Repository.go"), stage2.MongoBaseRepositoryContent(moduleName)); err != nil {
+				return fmt.Errorf("- [KO] création mongoadapter/Repository.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création mongoadapter/Repository.go -")
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathMongoAdapterDir, "Schema.go"), stage2.MongoSchemaContent(moduleName)); err != nil {
+				return fmt.Errorf("- [KO] création mongoadapter/Schema.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création mongoadapter/Schema.go -")
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathMongoAdapterDir, "Migrate.go"), stage2.MongoMigrateContent(moduleName)); err != nil {
+				return fmt.Errorf("- [KO] création mongoadapter/Migrate.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création mongoadapter/Migrate.go -")
+			}
 		}
-		if err := tools.WriteFileIfAbsent(pathMongoAdapter, stage2.MongoAdapterContent(moduleName)); err != nil {
-			return fmt.Errorf("- [KO] création MongoAdapter.go: %v", err)
+	}
+
+	// Créer RedisAdapter (cache)
+	if optionsStage2.Cache {
+		pathRedisAdapterDir := filepath.Join(pathFolderApi, "internal", "infrastructure", "adapter", "redisadapter")
+		pathRedisAdapter := filepath.Join(pathRedisAdapterDir, "RedisAdapter.go")
+		if err := tools.EnsureDir(pathRedisAdapterDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier redisadapter: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathRedisAdapter, stage2.RedisAdapterContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création RedisAdapter.go: %v", err)
 		} else {
-			fmt.Println("- [OK] création MongoAdapter.go -")
+			fmt.Println("- [OK] création RedisAdapter.go -")
+		}
+	}
+
+	// Créer SmtpAdapter (mailer)
+	if optionsStage2.Mailer {
+		pathSmtpAdapterDir := filepath.Join(pathFolderApi, "internal", "infrastructure", "adapter", "smtpadapter")
+		pathSmtpAdapter := filepath.Join(pathSmtpAdapterDir, "SmtpAdapter.go")
+		if err := tools.EnsureDir(pathSmtpAdapterDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier smtpadapter: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathSmtpAdapter, stage2.SmtpAdapterContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création SmtpAdapter.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création SmtpAdapter.go -")
+		}
+	}
+
+	// Créer S3Adapter (storage)
+	if optionsStage2.Storage {
+		pathS3AdapterDir := filepath.Join(pathFolderApi, "internal", "infrastructure", "adapter", "s3adapter")
+		pathS3Adapter := filepath.Join(pathS3AdapterDir, "S3Adapter.go")
+		if err := tools.EnsureDir(pathS3AdapterDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier s3adapter: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathS3Adapter, stage2.S3AdapterContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création S3Adapter.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création S3Adapter.go -")
 		}
 	}
 
@@ -494,6 +638,48 @@ func createAndSetApi() error {
 		}
 	}
 
+	// Créer CacheGateway
+	if optionsStage2.Cache {
+		pathCacheGatewayDir := filepath.Join(pathFolderApi, "internal", "application", "gateway", "cachegateway")
+		pathCacheGateway := filepath.Join(pathCacheGatewayDir, "CacheGateway.go")
+		if err := tools.EnsureDir(pathCacheGatewayDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier cachegateway: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathCacheGateway, stage2.CacheGatewayContent()); err != nil {
+			return fmt.Errorf("- [KO] création CacheGateway.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création CacheGateway.go -")
+		}
+	}
+
+	// Créer MailerGateway
+	if optionsStage2.Mailer {
+		pathMailerGatewayDir := filepath.Join(pathFolderApi, "internal", "application", "gateway", "mailergateway")
+		pathMailerGateway := filepath.Join(pathMailerGatewayDir, "MailerGateway.go")
+		if err := tools.EnsureDir(pathMailerGatewayDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier mailergateway: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathMailerGateway, stage2.MailerGatewayContent()); err != nil {
+			return fmt.Errorf("- [KO] création MailerGateway.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création MailerGateway.go -")
+		}
+	}
+
+	// Créer StorageGateway
+	if optionsStage2.Storage {
+		pathStorageGatewayDir := filepath.Join(pathFolderApi, "internal", "application", "gateway", "storagegateway")
+		pathStorageGateway := filepath.Join(pathStorageGatewayDir, "StorageGateway.go")
+		if err := tools.EnsureDir(pathStorageGatewayDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier storagegateway: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathStorageGateway, stage2.StorageGatewayContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création StorageGateway.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création StorageGateway.go -")
+		}
+	}
+
 	// Créer use cases
 	{
 		// NsevenUseCase
@@ -507,6 +693,46 @@ func createAndSetApi() error {
 		} else {
 			fmt.Println("- [OK] création NsevenUseCase.go -")
 		}
+
+		// HealthUseCase
+		pathHealthUseCaseDir := filepath.Join(pathFolderApi, "internal", "application", "usecase", "healthusecase")
+		pathHealthUseCase := filepath.Join(pathHealthUseCaseDir, "HealthUseCase.go")
+		if err := tools.EnsureDir(pathHealthUseCaseDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier healthusecase: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathHealthUseCase, stage2.HealthUseCaseContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création HealthUseCase.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création HealthUseCase.go -")
+		}
+	}
+
+	// Créer ContactUseCase (mailer)
+	if optionsStage2.Mailer {
+		pathContactUseCaseDir := filepath.Join(pathFolderApi, "internal", "application", "usecase", "contactusecase")
+		pathContactUseCase := filepath.Join(pathContactUseCaseDir, "ContactUseCase.go")
+		if err := tools.EnsureDir(pathContactUseCaseDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier contactusecase: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathContactUseCase, stage2.ContactUseCaseContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création ContactUseCase.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création ContactUseCase.go -")
+		}
+	}
+
+	// Créer UploadUseCase (storage)
+	if optionsStage2.Storage {
+		pathUploadUseCaseDir := filepath.Join(pathFolderApi, "internal", "application", "usecase", "uploadusecase")
+		pathUploadUseCase := filepath.Join(pathUploadUseCaseDir, "UploadUseCase.go")
+		if err := tools.EnsureDir(pathUploadUseCaseDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier uploadusecase: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathUploadUseCase, stage2.UploadUseCaseContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création UploadUseCase.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création UploadUseCase.go -")
+		}
 	}
 
 	// Créer controllers
@@ -552,39 +778,308 @@ func createAndSetApi() error {
 		} else {
 			fmt.Println("- [OK] création nsevencontroller/GetAllNseven.go -")
 		}
+
+		// HealthController
+		pathHealthControllerDir := filepath.Join(pathFolderApi, "internal", "application", "controller", "healthcontroller")
+		if err := tools.EnsureDir(pathHealthControllerDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier healthcontroller: %v", err)
+		}
+		for _, f := range []struct {
+			name    string
+			content string
+		}{
+			{"Controller.go", stage2.HealthControllerContent(moduleName)},
+			{"Live.go", stage2.HealthLiveContent(moduleName)},
+			{"Ready.go", stage2.HealthReadyContent(moduleName)},
+		} {
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathHealthControllerDir, f.name), f.content); err != nil {
+				return fmt.Errorf("- [KO] création healthcontroller/%s: %v", f.name, err)
+			} else {
+				fmt.Printf("- [OK] création healthcontroller/%s -\n", f.name)
+			}
+		}
+	}
+
+	// Créer ContactController (mailer)
+	if optionsStage2.Mailer {
+		pathContactControllerDir := filepath.Join(pathFolderApi, "internal", "application", "controller", "contactcontroller")
+		pathContactController := filepath.Join(pathContactControllerDir, "Controller.go")
+		pathContactSend := filepath.Join(pathContactControllerDir, "SendContact.go")
+		if err := tools.EnsureDir(pathContactControllerDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier contactcontroller: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(pathContactController, stage2.ContactControllerContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création contactcontroller/Controller.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création contactcontroller/Controller.go -")
+		}
+		if err := tools.WriteFileIfAbsent(pathContactSend, stage2.ContactSendContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création contactcontroller/SendContact.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création contactcontroller/SendContact.go -")
+		}
+	}
+
+	// Créer UploadController (storage)
+	if optionsStage2.Storage {
+		pathUploadControllerDir := filepath.Join(pathFolderApi, "internal", "application", "controller", "uploadcontroller")
+		if err := tools.EnsureDir(pathUploadControllerDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier uploadcontroller: %v", err)
+		}
+		for _, f := range []struct {
+			name    string
+			content string
+		}{
+			{"Controller.go", stage2.UploadControllerContent(moduleName)},
+			{"UploadFile.go", stage2.UploadFileContent(moduleName)},
+			{"GetFileUrl.go", stage2.GetFileUrlContent(moduleName)},
+			{"DeleteFile.go", stage2.DeleteFileContent(moduleName)},
+		} {
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathUploadControllerDir, f.name), f.content); err != nil {
+				return fmt.Errorf("- [KO] création uploadcontroller/%s: %v", f.name, err)
+			} else {
+				fmt.Printf("- [OK] création uploadcontroller/%s -\n", f.name)
+			}
+		}
 	}
 
 	// Créer domain
 	{
+		pathDomainErrorDir := filepath.Join(pathFolderApi, "internal", "domain", "domainerror")
+		if err := tools.EnsureDir(pathDomainErrorDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier domain/domainerror: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathDomainErrorDir, "DomainError.go"), stage2.DomainErrorContent()); err != nil {
+			return fmt.Errorf("- [KO] création DomainError.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création DomainError.go -")
+		}
+
+		pathPaginationDir := filepath.Join(pathFolderApi, "internal", "domain", "pagination")
+		if err := tools.EnsureDir(pathPaginationDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier domain/pagination: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathPaginationDir, "Pagination.go"), stage2.PaginationContent(moduleName)); err != nil {
+			return fmt.Errorf("- [KO] création Pagination.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création Pagination.go -")
+		}
+
 		pathNsevenDomainDir := filepath.Join(pathFolderApi, "internal", "domain", "nseven")
 		pathNsevenEntity := filepath.Join(pathNsevenDomainDir, "Nseven.go")
 		pathNsevenRepoInterface := filepath.Join(pathNsevenDomainDir, "NsevenRepositoryInterface.go")
 		if err := tools.EnsureDir(pathNsevenDomainDir); err != nil {
 			return fmt.Errorf("- [KO] création du dossier domain/nseven: %v", err)
 		}
-		if err := tools.WriteFileIfAbsent(pathNsevenEntity, stage2.NsevenEntityContent()); err != nil {
+		if err := tools.WriteFileIfAbsent(pathNsevenEntity, stage2.NsevenEntityContent(moduleName)); err != nil {
 			return fmt.Errorf("- [KO] création Nseven.go: %v", err)
 		} else {
 			fmt.Println("- [OK] création Nseven.go -")
 		}
-		if err := tools.WriteFileIfAbsent(pathNsevenRepoInterface, stage2.NsevenRepositoryInterfaceContent()); err != nil {
+		if err := tools.WriteFileIfAbsent(pathNsevenRepoInterface, stage2.NsevenRepositoryInterfaceContent(moduleName)); err != nil {
 			return fmt.Errorf("- [KO] création NsevenRepositoryInterface.go: %v", err)
 		} else {
 			fmt.Println("- [OK] création NsevenRepositoryInterface.go -")
 		}
+
+		// index et validateurs des collections MongoDB, la base SQL est décrite par les migrations
+		if !optionsStage2.Db.Sql() {
+			pathSchemaDir := filepath.Join(pathFolderApi, "internal", "domain", "schema")
+			if err := tools.EnsureDir(pathSchemaDir); err != nil {
+				return fmt.Errorf("- [KO] création du dossier domain/schema: %v", err)
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathSchemaDir, "Schema.go"), stage2.SchemaContent()); err != nil {
+				return fmt.Errorf("- [KO] création schema/Schema.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création schema/Schema.go -")
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathNsevenDomainDir, "Schema.go"), stage2.NsevenSchemaContent(moduleName)); err != nil {
+				return fmt.Errorf("- [KO] création nseven/Schema.go: %v", err)
+			} else {
+				fmt.Println("- [OK] création nseven/Schema.go -")
+			}
+		}
 	}
 
 	// Créer repository
 	{
 		pathNsevenRepositoryDir := filepath.Join(pathFolderApi, "internal", "infrastructure", "repository", "nsevenrepository")
-		pathNsevenRepository := filepath.Join(pathNsevenRepositoryDir, "MongoNsevenRepository.go")
+		nameNsevenRepository := "MongoNsevenRepository.go"
+		contentNsevenRepository := stage2.NsevenMongoRepositoryContent(moduleName)
+		if optionsStage2.Db.Sql() {
+			nameNsevenRepository = "SqlNsevenRepository.go"
+			contentNsevenRepository = stage2.NsevenSqlRepositoryContent(moduleName, optionsStage2.Db)
+		}
 		if err := tools.EnsureDir(pathNsevenRepositoryDir); err != nil {
 			return fmt.Errorf("- [KO] création du dossier nsevenrepository: %v", err)
 		}
-		if err := tools.WriteFileIfAbsent(pathNsevenRepository, stage2.NsevenMongoRepositoryContent(moduleName)); err != nil {
-			return fmt.Errorf("- [KO] création MongoNsevenRepository.go: %v", err)
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathNsevenRepositoryDir, nameNsevenRepository), contentNsevenRepository); err != nil {
+			return fmt.Errorf("- [KO] création %s: %v", nameNsevenRepository, err)
 		} else {
-			fmt.Println("- [OK] création MongoNsevenRepository.go -")
+			fmt.Printf("- [OK] création %s -\n", nameNsevenRepository)
+		}
+	}
+
+	// Créer les migrations (appliquées au démarrage de l'api ou par cmd/migrate)
+	{
+		pathMigrationsDir := filepath.Join(pathFolderApi, "migrations")
+		if err := tools.EnsureDir(pathMigrationsDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier migrations: %v", err)
+		}
+		files := []struct {
+			name    string
+			content string
+		}{
+			{"migrations.go", stage2.MongoMigrationsGoContent(moduleName)},
+		}
+		if optionsStage2.Db.Sql() {
+			files = []struct {
+				name    string
+				content string
+			}{
+				{"migrations.go", stage2.MigrationsGoContent()},
+				{"0001_create_nsevens.up.sql", stage2.NsevenMigrationUpContent(optionsStage2.Db)},
+				{"0001_create_nsevens.down.sql", stage2.NsevenMigrationDownContent()},
+			}
+		}
+		for _, f := range files {
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathMigrationsDir, f.name), f.content); err != nil {
+				return fmt.Errorf("- [KO] création migrations/%s: %v", f.name, err)
+			} else {
+				fmt.Printf("- [OK] création migrations/%s -\n", f.name)
+			}
+		}
+	}
+
+	// Créer l'authentification JWT (auth)
+	if optionsStage2.Auth {
+		pathInternal := filepath.Join(pathFolderApi, "internal")
+		nameUserRepository := "MongoUserRepository.go"
+		contentUserRepository := stage2.UserMongoRepositoryContent(moduleName)
+		if optionsStage2.Db.Sql() {
+			nameUserRepository = "SqlUserRepository.go"
+			contentUserRepository = stage2.UserSqlRepositoryContent(moduleName, optionsStage2.Db)
+		}
+		files := []struct {
+			dir     string
+			name    string
+			content string
+		}{
+			{filepath.Join("domain", "user"), "User.go", stage2.UserEntityContent(moduleName)},
+			{filepath.Join("domain", "user"), "UserRepositoryInterface.go", stage2.UserRepositoryInterfaceContent()},
+			{filepath.Join("infrastructure", "repository", "userrepository"), nameUserRepository, contentUserRepository},
+			{filepath.Join("application", "gateway", "tokengateway"), "TokenGateway.go", stage2.TokenGatewayContent(moduleName)},
+			{filepath.Join("application", "gateway", "passwordgateway"), "PasswordGateway.go", stage2.PasswordGatewayContent()},
+			{filepath.Join("infrastructure", "adapter", "jwtadapter"), "JwtAdapter.go", stage2.JwtAdapterContent(moduleName)},
+			{filepath.Join("infrastructure", "adapter", "bcryptadapter"), "BcryptAdapter.go", stage2.BcryptAdapterContent(moduleName)},
+			{filepath.Join("application", "middleware", "authmiddleware"), "AuthMiddleware.go", stage2.AuthMiddlewareContent(moduleName)},
+			{filepath.Join("application", "usecase", "authusecase"), "AuthUseCase.go", stage2.AuthUseCaseContent(moduleName)},
+			{filepath.Join("application", "controller", "authcontroller"), "Controller.go", stage2.AuthControllerContent(moduleName)},
+			{filepath.Join("application", "controller", "authcontroller"), "Dto.go", stage2.AuthDtoContent()},
+			{filepath.Join("application", "controller", "authcontroller"), "Register.go", stage2.AuthRegisterContent(moduleName)},
+			{filepath.Join("application", "controller", "authcontroller"), "Login.go", stage2.AuthLoginContent(moduleName)},
+			{filepath.Join("application", "controller", "authcontroller"), "Refresh.go", stage2.AuthRefreshContent(moduleName)},
+			{filepath.Join("application", "controller", "authcontroller"), "Me.go", stage2.AuthMeContent(moduleName)},
+			{filepath.Join("application", "controller", "authcontroller"), "UpdateRoles.go", stage2.AuthUpdateRolesContent(moduleName)},
+		}
+		if !optionsStage2.Db.Sql() {
+			files = append(files, struct {
+				dir     string
+				name    string
+				content string
+			}{filepath.Join("domain", "user"), "Schema.go", stage2.UserSchemaContent(moduleName)})
+		}
+		for _, f := range files {
+			if err := tools.EnsureDir(filepath.Join(pathInternal, f.dir)); err != nil {
+				return fmt.Errorf("- [KO] création du dossier %s: %v", filepath.Base(f.dir), err)
+			}
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathInternal, f.dir, f.name), f.content); err != nil {
+				return fmt.Errorf("- [KO] création %s/%s: %v", filepath.Base(f.dir), f.name, err)
+			} else {
+				fmt.Printf("- [OK] création %s/%s -\n", filepath.Base(f.dir), f.name)
+			}
+		}
+
+		// cmd/admin/main.go (make admin-promote), le rôle admin n'est jamais donné à l'inscription
+		pathCmdAdminDir := filepath.Join(pathFolderApi, "cmd", "admin")
+		if err := tools.EnsureDir(pathCmdAdminDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier cmd/admin: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathCmdAdminDir, "main.go"), stage2.AdminCmdContent(moduleName, optionsStage2.Db)); err != nil {
+			return fmt.Errorf("- [KO] création cmd/admin/main.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création cmd/admin/main.go -")
+		}
+
+		// table users des bases SQL
+		if optionsStage2.Db.Sql() {
+			pathMigrationsDir := filepath.Join(pathFolderApi, "migrations")
+			for _, f := range []struct {
+				name    string
+				content string
+			}{
+				{"0002_create_users.up.sql", stage2.UserMigrationUpContent(optionsStage2.Db)},
+				{"0002_create_users.down.sql", stage2.UserMigrationDownContent()},
+			} {
+				if err := tools.WriteFileIfAbsent(filepath.Join(pathMigrationsDir, f.name), f.content); err != nil {
+					return fmt.Errorf("- [KO] création migrations/%s: %v", f.name, err)
+				} else {
+					fmt.Printf("- [OK] création migrations/%s -\n", f.name)
+				}
+			}
+		}
+	}
+
+	// Créer data/.gitkeep (fichiers de la base sqlite)
+	if optionsStage2.Db == stage2.DbSqlite {
+		pathDataDir := filepath.Join(pathFolderApi, "data")
+		if err := tools.EnsureDir(pathDataDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier data: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathDataDir, ".gitkeep"), ""); err != nil {
+			return fmt.Errorf("- [KO] création data/.gitkeep: %v", err)
+		} else {
+			fmt.Println("- [OK] création data/.gitkeep -")
+		}
+	}
+
+	// Créer les templates de mails (embarqués dans le binaire)
+	if optionsStage2.Mailer {
+		pathMailTemplatesDir := filepath.Join(pathFolderApi, "mailtemplates")
+		if err := tools.EnsureDir(pathMailTemplatesDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier mailtemplates: %v", err)
+		}
+		for _, f := range []struct {
+			name    string
+			content string
+		}{
+			{"mailtemplates.go", stage2.MailTemplatesGoContent()},
+			{"contact.html", stage2.ContactMailHtmlContent()},
+			{"contact.txt", stage2.ContactMailTextContent()},
+		} {
+			if err := tools.WriteFileIfAbsent(filepath.Join(pathMailTemplatesDir, f.name), f.content); err != nil {
+				return fmt.Errorf("- [KO] création mailtemplates/%s: %v", f.name, err)
+			} else {
+				fmt.Printf("- [OK] création mailtemplates/%s -\n", f.name)
+			}
+		}
+	}
+
+	// Créer le package docs initial (importé par main.go, remplacé par swag init)
+	{
+		pathDocsDir := filepath.Join(pathFolderApi, "docs")
+		if err := tools.EnsureDir(pathDocsDir); err != nil {
+			return fmt.Errorf("- [KO] création du dossier docs: %v", err)
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathDocsDir, "docs.go"), stage2.DocsGoContent()); err != nil {
+			return fmt.Errorf("- [KO] création docs/docs.go: %v", err)
+		} else {
+			fmt.Println("- [OK] création docs/docs.go -")
+		}
+		if err := tools.WriteFileIfAbsent(filepath.Join(pathDocsDir, "swagger.json"), stage2.DocsSwaggerJsonContent()); err != nil {
+			return fmt.Errorf("- [KO] création docs/swagger.json: %v", err)
+		} else {
+			fmt.Println("- [OK] création docs/swagger.json -")
 		}
 	}
 
@@ -602,6 +1097,31 @@ func createAndSetApi() error {
 		}
 	}
 
+	// Générer la documentation swagger des controllers
+	{
+		if err := generateSwaggerDocs(pathFolderApi); err != nil {
+			fmt.Printf("- [WARN] swag init: %v, docs initial conservé (régénéré au lancement de l'api) -\n", err)
+		} else {
+			fmt.Println("- [OK] génération de la documentation swagger -")
+		}
+	}
+
+	return nil
+}
+
+// generateSwaggerDocs lance swag init comme .air.toml, avec le binaire swag s'il est installé
+// sinon avec la version de swag du go.mod de l'api
+func generateSwaggerDocs(pathFolderApi string) error {
+	args := []string{"init", "-o", "docs", "-g", "cmd/" + nameServiceApi + "/main.go", "--parseInternal", "--pd"}
+	cmd := exec.Command("swag", args...)
+	if _, err := exec.LookPath("swag"); err != nil {
+		cmd = exec.Command("go", append([]string{"run", "github.com/swaggo/swag/cmd/swag"}, args...)...)
+	}
+	cmd.Dir = pathFolderApi
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, strings.TrimSpace(string(output)))
+	}
 	return nil
 }
 
@@ -675,11 +1195,12 @@ func createAndSetFront() error {
 	// modification du package.json
 	{
 		pathPackageJson := filepath.Join(pathFolderFront, "package.json")
-		if err = stage2.ReplacePackageJsonScripts(pathPackageJson, stage2.PackageJsonScriptContent()); err != nil {
-			return fmt.Errorf("- [KO] écriture front/package-lock.json: %v", err)
-		} else {
-			fmt.Println("- [OK] modification front/package.json -")
+		report, err := stage2.MergePackageJsonScripts(pathPackageJson, stage2.PackageJsonScriptContent())
+		if err != nil {
+			return fmt.Errorf("- [KO] écriture front/package.json: %v", err)
 		}
+		fmt.Println("- [OK] modification front/package.json -")
+		report.Print()
 	}
 
 	// creation du entrypoint
@@ -713,13 +1234,13 @@ func createAndSetFront() error {
 	{
 		appEnv := filepath.Join(pathFolderFront, ".env")
 		appEnvDist := filepath.Join(pathFolderFront, ".env.dist")
-		if err = tools.WriteFileIfAbsent(appEnv, stage2.EnvFrontContent()); err != nil {
+		if err = tools.WriteFileIfAbsent(appEnv, stage2.EnvFrontContent(hostTraefikApi)); err != nil {
 			return fmt.Errorf("- [KO] création front/.env : %v", err)
 		} else {
 			fmt.Println("- [OK] création front/.env -")
 		}
 
-		if err = tools.WriteFileIfAbsent(appEnvDist, stage2.EnvFrontContent()); err != nil {
+		if err = tools.WriteFileIfAbsent(appEnvDist, stage2.EnvFrontContent(hostTraefikApi)); err != nil {
 			return fmt.Errorf("- [KO] création front/.env.dist : %v", err)
 		} else {
 			fmt.Println("- [OK] création front/.env.dist -")
@@ -738,6 +1259,11 @@ func validateUserForStart() error {
 	fmt.Printf("- Host du traefik front: %v\n", hostTraefikFront)
 	fmt.Printf("- Host du traefik Api: %v\n", hostTraefikApi)
 	fmt.Printf("- Version de node: %v\n", nodeVersion)
+	fmt.Printf("- Base de données: %v\n", optionsStage2.Db.Label())
+	fmt.Printf("- Cache redis: %v\n", optionsStage2.Cache)
+	fmt.Printf("- Mailer smtp: %v\n", optionsStage2.Mailer)
+	fmt.Printf("- Stockage S3/R2: %v\n", optionsStage2.Storage)
+	fmt.Printf("- Authentification JWT: %v\n", optionsStage2.Auth)
 	fmt.Printf("- Port pour tout les services traefik: %v\n", portLinkTraefik)
 
 	// validation des données de creation
@@ -795,4 +1321,10 @@ func init() {
 
 	starter2.Flags().StringVar(&hostTraefikApi, "hostApi", "", "format: host.extension => (requis) ")
 	_ = starter2.MarkFlagRequired("hostApi")
+
+	starter2.Flags().StringVar(&dbStage2, "db", string(stage2.DbMongo), "base de données de l'api: mongo, postgres ou sqlite")
+	starter2.Flags().BoolVar(&cacheStage2, "cache", false, "ajoute un cache redis à l'api")
+	starter2.Flags().BoolVar(&mailerStage2, "mailer", false, "ajoute l'envoi de mails (smtp) à l'api et mailpit en dev")
+	starter2.Flags().BoolVar(&storageStage2, "storage", false, "ajoute le stockage de fichiers S3/R2 à l'api et minio en dev")
+	starter2.Flags().BoolVar(&authStage2, "auth", false, "ajoute l'authentification JWT (utilisateurs, inscription, connexion) à l'api")
 }
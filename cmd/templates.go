@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/config"
+	"github.com/nsevendev/starter/internal/templates"
+	"github.com/spf13/cobra"
+	"sort"
+)
+
+var (
+	templatesDescription string
+	templatesOffline     bool
+	templatesVersion     string
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "gère le catalogue des templates (list, add, remove, versions, info)",
+	Long: `Gère le catalogue des templates disponibles pour init-temp-angssr-go.
+Le catalogue est enregistré dans la configuration du starter ($STARTER_CONFIG ou <config utilisateur>/starter/config.json).`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "liste les templates du catalogue",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(cfg.Templates))
+		for name := range cfg.Templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			tpl := cfg.Templates[name]
+			fmt.Printf("- %s (%s)\n", name, templates.DetectKind(tpl.Source))
+			fmt.Printf("    source: %s\n", tpl.Source)
+			if tpl.Description != "" {
+				fmt.Printf("    description: %s\n", tpl.Description)
+			}
+			if refs, err := templates.CachedRefs(name); err == nil && len(refs) > 0 {
+				fmt.Printf("    en cache: %v\n", refs)
+			}
+		}
+		return nil
+	},
+}
+
+var templatesAddCmd = &cobra.Command{
+	Use:   "add <nom> <source>",
+	Short: "ajoute ou modifie un template (url git, archive .tar.gz ou dossier local)",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		name, source := args[0], args[1]
+		tpl := cfg.Templates[name]
+		if tpl.Source != "" && tpl.Source != source {
+			// les pins ne valent que pour l'ancienne source
+			tpl.Pins = nil
+		}
+		tpl.Source = source
+		if templatesDescription != "" {
+			tpl.Description = templatesDescription
+		}
+		cfg.Templates[name] = tpl
+
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ template %s enregistré (%s)\n", name, templates.DetectKind(source))
+		return nil
+	},
+}
+
+var templatesRemoveCmd = &cobra.Command{
+	Use:   "remove <nom>",
+	Short: "supprime un template du catalogue",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if name == config.DefaultTemplateName {
+			return fmt.Errorf("le template par défaut %s ne peut pas être supprimé", name)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if _, err := cfg.Lookup(name); err != nil {
+			return err
+		}
+		delete(cfg.Templates, name)
+
+		if err := cfg.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ template %s supprimé\n", name)
+		return nil
+	},
+}
+
+var templatesVersionsCmd = &cobra.Command{
+	Use:   "versions [nom]",
+	Short: "liste les versions (tags) disponibles d'un template",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := config.DefaultTemplateName
+		if len(args) == 1 {
+			name = args[0]
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		tpl, err := cfg.Lookup(name)
+		if err != nil {
+			return err
+		}
+
+		versions, err := templates.ListVersions(name, tpl.Source, templatesOffline)
+		if err != nil {
+			return err
+		}
+		if len(versions) == 0 {
+			fmt.Printf("aucune version trouvée pour %s\n", name)
+			return nil
+		}
+
+		for _, version := range versions {
+			if _, pinned := tpl.Pins[version]; pinned {
+				fmt.Printf("- %s (épinglé)\n", version)
+			} else {
+				fmt.Printf("- %s\n", version)
+			}
+		}
+		return nil
+	},
+}
+
+var templatesInfoCmd = &cobra.Command{
+	Use:   "info [nom]",
+	Short: "affiche les informations et les variables du manifeste d'un template",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := config.DefaultTemplateName
+		if len(args) == 1 {
+			name = args[0]
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		tpl, err := cfg.Lookup(name)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Template: %s\n", name)
+		fmt.Printf("- source: %s (%s)\n", tpl.Source, templates.DetectKind(tpl.Source))
+		if tpl.Description != "" {
+			fmt.Printf("- description: %s\n", tpl.Description)
+		}
+		for ref, pin := range tpl.Pins {
+			fmt.Printf("- pin %s: commit %s, sha256 %s\n", ref, pin.Commit, pin.Sha256)
+		}
+
+		// sans version demandée on prend la plus récente
+		version := templatesVersion
+		if version == "" {
+			versions, err := templates.ListVersions(name, tpl.Source, templatesOffline)
+			if err != nil {
+				return err
+			}
+			if len(versions) == 0 {
+				return fmt.Errorf("aucune version disponible pour %s, précisez --version", name)
+			}
+			version = versions[0]
+		}
+
+		snap, err := templates.Fetch(templates.FetchOptions{
+			Name:    name,
+			Source:  tpl.Source,
+			Ref:     version,
+			Offline: templatesOffline,
+			Pin:     cfg.Pin(name, version),
+		})
+		if err != nil {
+			return err
+		}
+
+		manifest, err := templates.ReadManifest(snap)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("- version: %s\n", version)
+		if snap.Commit != "" {
+			fmt.Printf("- commit: %s\n", snap.Commit)
+		}
+		fmt.Printf("- sha256: %s\n", snap.Sha256)
+
+		if manifest == nil {
+			fmt.Printf("\nAucun manifeste (%s) dans ce template.\n", templates.ManifestFile)
+			return nil
+		}
+		if manifest.Description != "" {
+			fmt.Printf("\n%s\n", manifest.Description)
+		}
+		fmt.Println("\nVariables:")
+		for _, v := range manifest.Variables {
+			line := fmt.Sprintf("  - %s", v.Name)
+			if v.Flag != "" {
+				line += fmt.Sprintf(" (--%s)", v.Flag)
+			}
+			if v.Required {
+				line += " [requis]"
+			}
+			if v.Default != "" {
+				line += fmt.Sprintf(" [défaut: %s]", v.Default)
+			}
+			if v.Description != "" {
+				line += ": " + v.Description
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+func init() {
+	templatesAddCmd.Flags().StringVar(&templatesDescription, "description", "", "description du template")
+	templatesVersionsCmd.Flags().BoolVar(&templatesOffline, "offline", false, "utilise uniquement le cache local")
+	templatesInfoCmd.Flags().BoolVar(&templatesOffline, "offline", false, "utilise uniquement le cache local")
+	templatesInfoCmd.Flags().StringVar(&templatesVersion, "version", "", "version à inspecter (défaut: la plus récente)")
+
+	templatesCmd.AddCommand(templatesListCmd, templatesAddCmd, templatesRemoveCmd, templatesVersionsCmd, templatesInfoCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
@@ -2,8 +2,13 @@ module github.com/nsevendev/starter
 
 go 1.24.4
 
+require (
+	github.com/spf13/cobra v1.10.1
+	golang.org/x/mod v0.27.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/cobra v1.10.1 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 )
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultTemplateName nom du template utilisé par init-temp-angssr-go
+const DefaultTemplateName = "temp-angssr-go"
+
+// DefaultTemplateSource source git du template par défaut
+const DefaultTemplateSource = "https://github.com/nsevendev/temp-angssr-go.git"
+
+// Pin épingle un ref de template sur un commit et/ou un checksum du contenu
+type Pin struct {
+	Commit string `json:"commit,omitempty"`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+// Template source d'un template et ses refs épinglés
+type Template struct {
+	Source      string         `json:"source"`
+	Description string         `json:"description,omitempty"`
+	Pins        map[string]Pin `json:"pins,omitempty"`
+}
+
+// Config configuration persistante du starter
+type Config struct {
+	Templates map[string]Template `json:"templates"`
+}
+
+// Path retourne le chemin du fichier de configuration
+// $STARTER_CONFIG si défini, sinon <config utilisateur>/starter/config.json
+func Path() (string, error) {
+	if p := os.Getenv("STARTER_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("récupération du dossier de configuration: %w", err)
+	}
+	return filepath.Join(dir, "starter", "config.json"), nil
+}
+
+// Load lit la configuration, un fichier absent donne la configuration par défaut
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{Templates: map[string]Template{}}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("lecture de %s: %w", path, err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing de %s: %w", path, err)
+		}
+		if cfg.Templates == nil {
+			cfg.Templates = map[string]Template{}
+		}
+	}
+
+	// le template par défaut est toujours disponible
+	if _, ok := cfg.Templates[DefaultTemplateName]; !ok {
+		cfg.Templates[DefaultTemplateName] = Template{
+			Source:      DefaultTemplateSource,
+			Description: "angular ssr + api go + mongo, redis, docker, r2, mailer",
+		}
+	}
+
+	return cfg, nil
+}
+
+// Save écrit la configuration sur le disque
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("création du dossier %s: %w", filepath.Dir(path), err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serialization de la configuration: %w", err)
+	}
+	data = append(data, '\n')
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("écriture de %s: %w", path, err)
+	}
+	return nil
+}
+
+// Lookup retourne un template du catalogue
+func (c *Config) Lookup(name string) (Template, error) {
+	tpl, ok := c.Templates[name]
+	if !ok {
+		return Template{}, fmt.Errorf("template %s inconnu (voir: starter templates list)", name)
+	}
+	return tpl, nil
+}
+
+// Pin retourne le pin d'un template pour un ref, nil si aucun
+func (c *Config) Pin(name, ref string) *Pin {
+	tpl, ok := c.Templates[name]
+	if !ok {
+		return nil
+	}
+	pin, ok := tpl.Pins[ref]
+	if !ok {
+		return nil
+	}
+	return &pin
+}
+
+// SetPin enregistre le pin d'un template pour un ref
+func (c *Config) SetPin(name, ref string, pin Pin) {
+	tpl := c.Templates[name]
+	if tpl.Pins == nil {
+		tpl.Pins = map[string]Pin{}
+	}
+	tpl.Pins[ref] = pin
+	c.Templates[name] = tpl
+}
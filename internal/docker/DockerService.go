@@ -87,7 +87,7 @@ func PrintDockerHints(project string) {
 	}
 	if !DockerNetworkExists(network) {
 		fmt.Printf("[INFO] Réseau externe '%s' absent. Créez-le avant de lancer: docker network create %s\n", network, network)
-		if tools.AskYesNo(fmt.Sprintf("  Voulez vous creer le reseau %v => %v ? [o/N]: ", network), true) {
+		if tools.AskYesNo(fmt.Sprintf("  Voulez vous creer le reseau %v => %v ? [o/N]: ", project, network), true) {
 			cmd := exec.Command("docker", "network", "create", network)
 			cmd.Stdout = os.Stdout
 			cmd.Stderr = os.Stderr
@@ -0,0 +1,179 @@
+package gomodule
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"golang.org/x/mod/modfile"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// skippedDirs dossiers jamais parcourus lors du renommage
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"tmp":          true,
+	"node_modules": true,
+	"testdata":     true,
+	"dist":         true,
+}
+
+// RenameReport résultat d'un renommage de module
+type RenameReport struct {
+	OldModule     string
+	NewModule     string
+	FilesModified []string
+}
+
+// ModulePath retourne le chemin du module déclaré dans un go.mod
+func ModulePath(goModPath string) (string, error) {
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return "", fmt.Errorf("lecture de %s: %w", goModPath, err)
+	}
+	path := modfile.ModulePath(data)
+	if path == "" {
+		return "", fmt.Errorf("directive module absente de %s", goModPath)
+	}
+	return path, nil
+}
+
+// RenameModule renomme le module Go situé dans root:
+// - réécrit la directive module du go.mod avec modfile
+// - réécrit les imports du module (et de ses sous-packages) via l'AST, sans toucher aux chaînes ni aux commentaires
+// - vérifie que chaque fichier modifié est toujours un fichier Go valide
+func RenameModule(root, newModule string) (*RenameReport, error) {
+	goModPath := filepath.Join(root, "go.mod")
+	data, err := os.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", goModPath, err)
+	}
+
+	mod, err := modfile.Parse(goModPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing de %s: %w", goModPath, err)
+	}
+	if mod.Module == nil {
+		return nil, fmt.Errorf("directive module absente de %s", goModPath)
+	}
+
+	report := &RenameReport{OldModule: mod.Module.Mod.Path, NewModule: newModule}
+	if report.OldModule == newModule {
+		return report, nil
+	}
+
+	// réécriture des imports en mémoire: aucun fichier n'est écrit tant que tous ne sont pas valides,
+	// pour ne rien laisser à moitié renommé en cas d'erreur
+	type rewrite struct {
+		path    string
+		content []byte
+	}
+	var rewrites []rewrite
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != root && (skippedDirs[name] || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			// un go.mod imbriqué est un autre module
+			if path != root {
+				if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		content, modified, err := rewriteImports(path, report.OldModule, newModule)
+		if err != nil {
+			return err
+		}
+		if modified {
+			rewrites = append(rewrites, rewrite{path: path, content: content})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := mod.AddModuleStmt(newModule); err != nil {
+		return nil, fmt.Errorf("renommage du module dans %s: %w", goModPath, err)
+	}
+	out, err := mod.Format()
+	if err != nil {
+		return nil, fmt.Errorf("formatage de %s: %w", goModPath, err)
+	}
+
+	for _, r := range rewrites {
+		if err := os.WriteFile(r.path, r.content, 0o644); err != nil {
+			return nil, fmt.Errorf("écriture de %s: %w", r.path, err)
+		}
+		report.FilesModified = append(report.FilesModified, r.path)
+	}
+	if err := os.WriteFile(goModPath, out, 0o644); err != nil {
+		return nil, fmt.Errorf("écriture de %s: %w", goModPath, err)
+	}
+
+	return report, nil
+}
+
+// rewriteImports remplace les chemins d'import oldModule[/...] par newModule[/...] dans un fichier,
+// retourne le contenu réécrit sans l'écrire, modified vaut false si le fichier n'importe pas oldModule
+func rewriteImports(path, oldModule, newModule string) ([]byte, bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("lecture de %s: %w", path, err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return nil, false, fmt.Errorf("parsing de %s: %w", path, err)
+	}
+
+	type edit struct {
+		start, end int
+		value      string
+	}
+	var edits []edit
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, false, fmt.Errorf("import invalide dans %s: %s", path, spec.Path.Value)
+		}
+		if importPath != oldModule && !strings.HasPrefix(importPath, oldModule+"/") {
+			continue
+		}
+		edits = append(edits, edit{
+			start: fset.Position(spec.Path.Pos()).Offset,
+			end:   fset.Position(spec.Path.End()).Offset,
+			value: strconv.Quote(newModule + strings.TrimPrefix(importPath, oldModule)),
+		})
+	}
+	if len(edits) == 0 {
+		return nil, false, nil
+	}
+
+	// application de la fin vers le début pour garder les offsets valides
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	out := src
+	for _, e := range edits {
+		out = append(out[:e.start:e.start], append([]byte(e.value), out[e.end:]...)...)
+	}
+
+	// vérification du résultat avant écriture
+	if _, err := parser.ParseFile(token.NewFileSet(), path, out, parser.ParseComments); err != nil {
+		return nil, false, fmt.Errorf("fichier invalide après renommage %s: %w", path, err)
+	}
+	return out, true, nil
+}
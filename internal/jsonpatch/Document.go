@@ -0,0 +1,344 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+type kind int
+
+const (
+	kindScalar kind = iota
+	kindObject
+	kindArray
+)
+
+// node valeur JSON ordonnée
+// raw contient le texte d'origine, réutilisé tel quel tant que le noeud n'est pas modifié
+type node struct {
+	kind    kind
+	members []*member // objet, dans l'ordre du fichier
+	items   []*node   // tableau
+	raw     []byte
+	dirty   bool
+}
+
+type member struct {
+	key    string
+	rawKey []byte
+	value  *node
+}
+
+// Document fichier JSON patchable qui conserve l'ordre des clés, l'indentation et le retour à la ligne final
+type Document struct {
+	root    *node
+	prefix  []byte // espaces avant la valeur racine
+	suffix  []byte // espaces après la valeur racine (retour à la ligne final)
+	indent  string
+	newline string
+}
+
+var indentPattern = regexp.MustCompile(`\n([ \t]+)\S`)
+
+// Parse lit un document JSON et détecte son format (indentation, fins de ligne)
+func Parse(data []byte) (*Document, error) {
+	if !json.Valid(data) {
+		return nil, errors.New("JSON invalide")
+	}
+
+	p := &parser{data: data}
+	p.skipSpaces()
+	start := p.pos
+	root := p.value()
+	end := p.pos
+	p.skipSpaces()
+
+	doc := &Document{
+		root:    root,
+		prefix:  data[:start],
+		suffix:  data[end:],
+		indent:  "  ",
+		newline: "\n",
+	}
+	if m := indentPattern.FindSubmatch(data); m != nil {
+		doc.indent = string(m[1])
+	}
+	if bytes.Contains(data, []byte("\r\n")) {
+		doc.newline = "\r\n"
+	}
+	return doc, nil
+}
+
+// ParseFile lit et parse un fichier JSON
+func ParseFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture %s: %w", path, err)
+	}
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse JSON %s: %w", path, err)
+	}
+	return doc, nil
+}
+
+// PatchFile applique des opérations à un fichier JSON en conservant son format
+func PatchFile(path string, ops ...Operation) error {
+	doc, err := ParseFile(path)
+	if err != nil {
+		return err
+	}
+	if err := doc.Apply(ops...); err != nil {
+		return fmt.Errorf("patch %s: %w", path, err)
+	}
+	return doc.WriteFile(path)
+}
+
+// WriteFile écrit le document dans un fichier
+func (d *Document) WriteFile(path string) error {
+	if err := os.WriteFile(path, d.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("écriture %s: %w", path, err)
+	}
+	return nil
+}
+
+// Bytes sérialise le document
+// les parties non modifiées sont restituées à l'identique
+func (d *Document) Bytes() []byte {
+	var b bytes.Buffer
+	b.Write(d.prefix)
+	d.write(&b, d.root, 0)
+	b.Write(d.suffix)
+	return b.Bytes()
+}
+
+// Has indique si le pointeur JSON (RFC 6901) désigne une valeur existante
+func (d *Document) Has(path string) bool {
+	_, err := d.find(d.root, path)
+	return err == nil
+}
+
+// Get retourne la valeur désignée par le pointeur JSON décodée en type Go générique
+func (d *Document) Get(path string) (any, error) {
+	var v any
+	if err := d.Decode(path, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Keys retourne les clés d'un objet dans l'ordre du fichier
+func (d *Document) Keys(path string) ([]string, error) {
+	n, err := d.find(d.root, path)
+	if err != nil {
+		return nil, err
+	}
+	if n.kind != kindObject {
+		return nil, fmt.Errorf("%s n'est pas un objet", path)
+	}
+	keys := make([]string, 0, len(n.members))
+	for _, m := range n.members {
+		keys = append(keys, m.key)
+	}
+	return keys, nil
+}
+
+// Decode décode la valeur désignée par le pointeur JSON dans v
+func (d *Document) Decode(path string, v any) error {
+	n, err := d.find(d.root, path)
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	d.write(&b, n, 0)
+	return json.Unmarshal(b.Bytes(), v)
+}
+
+func (d *Document) write(b *bytes.Buffer, n *node, depth int) {
+	if n.raw != nil && !n.dirty {
+		b.Write(n.raw)
+		return
+	}
+
+	switch n.kind {
+	case kindObject:
+		if len(n.members) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteString("{")
+		for i, m := range n.members {
+			b.WriteString(d.newline)
+			d.writeIndent(b, depth+1)
+			b.Write(m.rawKey)
+			b.WriteString(": ")
+			d.write(b, m.value, depth+1)
+			if i < len(n.members)-1 {
+				b.WriteString(",")
+			}
+		}
+		b.WriteString(d.newline)
+		d.writeIndent(b, depth)
+		b.WriteString("}")
+	case kindArray:
+		if len(n.items) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[")
+		for i, item := range n.items {
+			b.WriteString(d.newline)
+			d.writeIndent(b, depth+1)
+			d.write(b, item, depth+1)
+			if i < len(n.items)-1 {
+				b.WriteString(",")
+			}
+		}
+		b.WriteString(d.newline)
+		d.writeIndent(b, depth)
+		b.WriteString("]")
+	default:
+		b.Write(n.raw)
+	}
+}
+
+func (d *Document) writeIndent(b *bytes.Buffer, depth int) {
+	for i := 0; i < depth; i++ {
+		b.WriteString(d.indent)
+	}
+}
+
+// valueNode convertit une valeur Go en noeud (formaté à la sérialisation)
+func valueNode(v any) (*node, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("marshal de la valeur: %w", err)
+	}
+	p := &parser{data: bytes.TrimSpace(b.Bytes())}
+	n := p.value()
+	n.reset()
+	return n, nil
+}
+
+// reset oublie le texte d'origine des conteneurs pour les reformater à leur nouvelle profondeur
+func (n *node) reset() {
+	if n.kind == kindScalar {
+		return
+	}
+	n.raw = nil
+	for _, m := range n.members {
+		m.value.reset()
+	}
+	for _, item := range n.items {
+		item.reset()
+	}
+}
+
+func (n *node) clone() *node {
+	c := &node{kind: n.kind, raw: n.raw, dirty: n.dirty}
+	for _, m := range n.members {
+		c.members = append(c.members, &member{key: m.key, rawKey: m.rawKey, value: m.value.clone()})
+	}
+	for _, item := range n.items {
+		c.items = append(c.items, item.clone())
+	}
+	return c
+}
+
+func (n *node) member(key string) (int, *member) {
+	for i, m := range n.members {
+		if m.key == key {
+			return i, m
+		}
+	}
+	return -1, nil
+}
+
+// parser lecture d'un JSON déjà validé par json.Valid
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *parser) value() *node {
+	p.skipSpaces()
+	start := p.pos
+	n := &node{}
+
+	switch p.data[p.pos] {
+	case '{':
+		n.kind = kindObject
+		p.pos++
+		p.skipSpaces()
+		for p.data[p.pos] != '}' {
+			keyStart := p.pos
+			p.str()
+			rawKey := p.data[keyStart:p.pos]
+			var key string
+			_ = json.Unmarshal(rawKey, &key)
+			p.skipSpaces()
+			p.pos++ // ':'
+			n.members = append(n.members, &member{key: key, rawKey: rawKey, value: p.value()})
+			p.skipSpaces()
+			if p.data[p.pos] == ',' {
+				p.pos++
+				p.skipSpaces()
+			}
+		}
+		p.pos++
+	case '[':
+		n.kind = kindArray
+		p.pos++
+		p.skipSpaces()
+		for p.data[p.pos] != ']' {
+			n.items = append(n.items, p.value())
+			p.skipSpaces()
+			if p.data[p.pos] == ',' {
+				p.pos++
+				p.skipSpaces()
+			}
+		}
+		p.pos++
+	case '"':
+		p.str()
+	default:
+		for p.pos < len(p.data) {
+			c := p.data[p.pos]
+			if c == ',' || c == '}' || c == ']' || c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+				break
+			}
+			p.pos++
+		}
+	}
+
+	n.raw = p.data[start:p.pos]
+	return n
+}
+
+func (p *parser) str() {
+	p.pos++ // '"' ouvrant
+	for p.data[p.pos] != '"' {
+		if p.data[p.pos] == '\\' {
+			p.pos++
+		}
+		p.pos++
+	}
+	p.pos++
+}
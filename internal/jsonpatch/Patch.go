@@ -0,0 +1,282 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Operation opération JSON Patch (RFC 6902)
+type Operation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	From  string `json:"from,omitempty"`
+	Value any    `json:"value"` // présent même à null pour add, replace et test, absent des autres opérations
+}
+
+// MarshalJSON encode value pour add, replace et test même s'il vaut null (RFC 6902 §4), pas pour les autres opérations
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	if requiresValue(o.Op) {
+		return json.Marshal(operation(o))
+	}
+	return json.Marshal(struct {
+		Op   string `json:"op"`
+		Path string `json:"path"`
+		From string `json:"from,omitempty"`
+	}{o.Op, o.Path, o.From})
+}
+
+// requiresValue indique que l'opération exige le membre value
+func requiresValue(op string) bool {
+	return op == "add" || op == "replace" || op == "test"
+}
+
+// Add ajoute (ou remplace pour un objet) la valeur à path
+func Add(path string, value any) Operation {
+	return Operation{Op: "add", Path: path, Value: value}
+}
+
+// Remove supprime la valeur à path
+func Remove(path string) Operation {
+	return Operation{Op: "remove", Path: path}
+}
+
+// Replace remplace la valeur existante à path en gardant sa position
+func Replace(path string, value any) Operation {
+	return Operation{Op: "replace", Path: path, Value: value}
+}
+
+// Move déplace la valeur de from vers path
+func Move(from, path string) Operation {
+	return Operation{Op: "move", From: from, Path: path}
+}
+
+// Copy copie la valeur de from vers path
+func Copy(from, path string) Operation {
+	return Operation{Op: "copy", From: from, Path: path}
+}
+
+// Test vérifie que la valeur à path est égale à value
+func Test(path string, value any) Operation {
+	return Operation{Op: "test", Path: path, Value: value}
+}
+
+// DecodePatch lit un document JSON Patch (tableau d'opérations),
+// add, replace et test sans membre value sont refusés (une valeur null doit être explicite)
+func DecodePatch(data []byte) ([]Operation, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, fmt.Errorf("parse JSON Patch: %w", err)
+	}
+
+	ops := make([]Operation, len(raws))
+	for i, raw := range raws {
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &members); err != nil {
+			return nil, fmt.Errorf("parse JSON Patch: opération %d: %w", i, err)
+		}
+		if err := json.Unmarshal(raw, &ops[i]); err != nil {
+			return nil, fmt.Errorf("parse JSON Patch: opération %d: %w", i, err)
+		}
+		if _, ok := members["value"]; !ok && requiresValue(ops[i].Op) {
+			return nil, fmt.Errorf("parse JSON Patch: opération %d (%s %s): membre value absent", i, ops[i].Op, ops[i].Path)
+		}
+	}
+	return ops, nil
+}
+
+// Apply applique les opérations dans l'ordre
+// l'application est atomique: en cas d'erreur le document n'est pas modifié
+func (d *Document) Apply(ops ...Operation) error {
+	root := d.root.clone()
+	for i, op := range ops {
+		var err error
+		root, err = d.apply(root, op)
+		if err != nil {
+			return fmt.Errorf("opération %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	d.root = root
+	return nil
+}
+
+func (d *Document) apply(root *node, op Operation) (*node, error) {
+	switch op.Op {
+	case "add":
+		value, err := valueNode(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return d.add(root, op.Path, value)
+	case "remove":
+		_, err := d.remove(root, op.Path)
+		return root, err
+	case "replace":
+		value, err := valueNode(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		return d.replace(root, op.Path, value)
+	case "move":
+		if op.Path != op.From && strings.HasPrefix(op.Path+"/", op.From+"/") {
+			return nil, fmt.Errorf("impossible de déplacer %s dans un de ses enfants", op.From)
+		}
+		if op.Path == op.From {
+			_, err := d.find(root, op.From)
+			return root, err
+		}
+		value, err := d.remove(root, op.From)
+		if err != nil {
+			return nil, err
+		}
+		value.reset()
+		return d.add(root, op.Path, value)
+	case "copy":
+		value, err := d.find(root, op.From)
+		if err != nil {
+			return nil, err
+		}
+		value = value.clone()
+		value.reset()
+		return d.add(root, op.Path, value)
+	case "test":
+		return root, d.test(root, op.Path, op.Value)
+	}
+	return nil, fmt.Errorf("opération inconnue %q", op.Op)
+}
+
+func (d *Document) add(root *node, path string, value *node) (*node, error) {
+	chain, last, err := d.resolve(root, path)
+	if err != nil {
+		return nil, err
+	}
+	if chain == nil {
+		value.dirty = true
+		return value, nil
+	}
+
+	parent := chain[len(chain)-1]
+	switch parent.kind {
+	case kindObject:
+		if _, m := parent.member(last); m != nil {
+			m.value = value
+		} else {
+			rawKey, _ := json.Marshal(last)
+			parent.members = append(parent.members, &member{key: last, rawKey: rawKey, value: value})
+		}
+	case kindArray:
+		i, err := parent.index(last, true)
+		if err != nil {
+			return nil, err
+		}
+		parent.items = append(parent.items[:i], append([]*node{value}, parent.items[i:]...)...)
+	default:
+		return nil, fmt.Errorf("le parent de %s n'est ni un objet ni un tableau", path)
+	}
+
+	markDirty(chain)
+	return root, nil
+}
+
+func (d *Document) remove(root *node, path string) (*node, error) {
+	chain, last, err := d.resolve(root, path)
+	if err != nil {
+		return nil, err
+	}
+	if chain == nil {
+		return nil, fmt.Errorf("impossible de supprimer la racine")
+	}
+
+	parent := chain[len(chain)-1]
+	var removed *node
+	switch parent.kind {
+	case kindObject:
+		i, m := parent.member(last)
+		if m == nil {
+			return nil, fmt.Errorf("clé %q absente", last)
+		}
+		removed = m.value
+		parent.members = append(parent.members[:i], parent.members[i+1:]...)
+	case kindArray:
+		i, err := parent.index(last, false)
+		if err != nil {
+			return nil, err
+		}
+		removed = parent.items[i]
+		parent.items = append(parent.items[:i], parent.items[i+1:]...)
+	default:
+		return nil, fmt.Errorf("le parent de %s n'est ni un objet ni un tableau", path)
+	}
+
+	markDirty(chain)
+	return removed, nil
+}
+
+func (d *Document) replace(root *node, path string, value *node) (*node, error) {
+	chain, last, err := d.resolve(root, path)
+	if err != nil {
+		return nil, err
+	}
+	if chain == nil {
+		value.dirty = true
+		return value, nil
+	}
+
+	parent := chain[len(chain)-1]
+	switch parent.kind {
+	case kindObject:
+		_, m := parent.member(last)
+		if m == nil {
+			return nil, fmt.Errorf("clé %q absente", last)
+		}
+		m.value = value
+	case kindArray:
+		i, err := parent.index(last, false)
+		if err != nil {
+			return nil, err
+		}
+		parent.items[i] = value
+	default:
+		return nil, fmt.Errorf("le parent de %s n'est ni un objet ni un tableau", path)
+	}
+
+	markDirty(chain)
+	return root, nil
+}
+
+func (d *Document) test(root *node, path string, expected any) error {
+	n, err := d.find(root, path)
+	if err != nil {
+		return err
+	}
+
+	tmp := &Document{root: n, indent: d.indent, newline: d.newline}
+	var actual any
+	if err := tmp.Decode("", &actual); err != nil {
+		return err
+	}
+
+	// normalisation de la valeur attendue via un aller-retour JSON
+	data, err := json.Marshal(expected)
+	if err != nil {
+		return fmt.Errorf("marshal de la valeur: %w", err)
+	}
+	var want any
+	if err := json.Unmarshal(data, &want); err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(actual, want) {
+		return fmt.Errorf("valeur différente de celle attendue")
+	}
+	return nil
+}
+
+// markDirty force la réécriture des conteneurs modifiés et de leurs parents
+func markDirty(chain []*node) {
+	for _, n := range chain {
+		n.dirty = true
+	}
+}
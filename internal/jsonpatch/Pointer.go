@@ -0,0 +1,112 @@
+package jsonpatch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pointer construit un pointeur JSON (RFC 6901) en échappant chaque segment
+// ex: Pointer("devDependencies", "@tailwindcss/postcss") => "/devDependencies/@tailwindcss~1postcss"
+func Pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(t))
+	}
+	return b.String()
+}
+
+// splitPointer découpe un pointeur JSON en segments décodés
+func splitPointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("pointeur JSON invalide %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens, nil
+}
+
+// find retourne le noeud désigné par un pointeur
+func (d *Document) find(root *node, path string) (*node, error) {
+	tokens, err := splitPointer(path)
+	if err != nil {
+		return nil, err
+	}
+	n := root
+	for i, t := range tokens {
+		child, err := n.child(t)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", Pointer(tokens[:i+1]...), err)
+		}
+		n = child
+	}
+	return n, nil
+}
+
+// resolve retourne la chaîne des noeuds parents d'un pointeur et son dernier segment
+func (d *Document) resolve(root *node, path string) ([]*node, string, error) {
+	tokens, err := splitPointer(path)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(tokens) == 0 {
+		return nil, "", nil
+	}
+
+	chain := []*node{root}
+	n := root
+	for i, t := range tokens[:len(tokens)-1] {
+		child, err := n.child(t)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", Pointer(tokens[:i+1]...), err)
+		}
+		n = child
+		chain = append(chain, n)
+	}
+	return chain, tokens[len(tokens)-1], nil
+}
+
+func (n *node) child(token string) (*node, error) {
+	switch n.kind {
+	case kindObject:
+		if _, m := n.member(token); m != nil {
+			return m.value, nil
+		}
+		return nil, fmt.Errorf("clé %q absente", token)
+	case kindArray:
+		i, err := n.index(token, false)
+		if err != nil {
+			return nil, err
+		}
+		return n.items[i], nil
+	}
+	return nil, fmt.Errorf("segment %q sur une valeur qui n'est ni un objet ni un tableau", token)
+}
+
+// index décode un index de tableau, "-" (fin du tableau) n'est accepté qu'en insertion
+func (n *node) index(token string, insert bool) (int, error) {
+	if token == "-" && insert {
+		return len(n.items), nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("index de tableau invalide %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return 0, fmt.Errorf("index de tableau invalide %q", token)
+	}
+	max := len(n.items) - 1
+	if insert {
+		max = len(n.items)
+	}
+	if i > max {
+		return 0, fmt.Errorf("index %d hors du tableau (taille %d)", i, len(n.items))
+	}
+	return i, nil
+}
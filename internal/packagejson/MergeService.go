@@ -0,0 +1,192 @@
+package packagejson
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/jsonpatch"
+)
+
+// Policy règle de fusion d'une clé
+type Policy string
+
+const (
+	PolicySet          Policy = "set"           // ajoute ou écrase la valeur
+	PolicyKeepExisting Policy = "keep-existing" // ajoute la valeur uniquement si la clé est absente
+	PolicyRemove       Policy = "remove"        // supprime la clé si elle existe
+)
+
+// Entry clé à fusionner dans une section du package.json
+type Entry struct {
+	Key    string
+	Value  string
+	Policy Policy
+}
+
+// Set ajoute ou écrase une clé
+func Set(key, value string) Entry {
+	return Entry{Key: key, Value: value, Policy: PolicySet}
+}
+
+// Keep ajoute une clé uniquement si elle n'existe pas déjà
+func Keep(key, value string) Entry {
+	return Entry{Key: key, Value: value, Policy: PolicyKeepExisting}
+}
+
+// Delete supprime une clé
+func Delete(key string) Entry {
+	return Entry{Key: key, Policy: PolicyRemove}
+}
+
+// Merge modifications à appliquer, les clés absentes des listes ne sont jamais touchées
+type Merge struct {
+	Scripts         []Entry
+	Dependencies    []Entry
+	DevDependencies []Entry
+}
+
+// Action résultat de la fusion d'une clé
+type Action string
+
+const (
+	ActionAdded     Action = "ajouté"
+	ActionUpdated   Action = "modifié"
+	ActionKept      Action = "conservé"
+	ActionRemoved   Action = "supprimé"
+	ActionUnchanged Action = "inchangé"
+)
+
+// Change modification d'une clé
+type Change struct {
+	Section string
+	Key     string
+	Action  Action
+	Old     string
+	New     string
+}
+
+// Report liste des modifications effectuées par une fusion
+type Report struct {
+	Path    string
+	Changes []Change
+}
+
+// MergeFile fusionne les scripts et dépendances dans un package.json
+// le reste du fichier (ordre des clés, indentation) est conservé
+func MergeFile(path string, m Merge) (*Report, error) {
+	doc, err := jsonpatch.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Path: path}
+	sections := []struct {
+		name    string
+		entries []Entry
+	}{
+		{"scripts", m.Scripts},
+		{"dependencies", m.Dependencies},
+		{"devDependencies", m.DevDependencies},
+	}
+
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		if err := mergeSection(doc, section.name, section.entries, report); err != nil {
+			return nil, fmt.Errorf("fusion de %s dans %s: %w", section.name, path, err)
+		}
+	}
+
+	if err := doc.WriteFile(path); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func mergeSection(doc *jsonpatch.Document, section string, entries []Entry, report *Report) error {
+	sectionPath := jsonpatch.Pointer(section)
+
+	current := map[string]string{}
+	if doc.Has(sectionPath) {
+		if err := doc.Decode(sectionPath, &current); err != nil {
+			return fmt.Errorf("%s n'est pas un objet de chaînes: %w", section, err)
+		}
+	} else {
+		// rien à supprimer dans une section absente, on ne la crée que si une clé doit y être ajoutée
+		onlyRemove := true
+		for _, e := range entries {
+			if e.Policy != PolicyRemove {
+				onlyRemove = false
+			}
+		}
+		if onlyRemove {
+			return nil
+		}
+		if err := doc.Apply(jsonpatch.Add(sectionPath, map[string]string{})); err != nil {
+			return err
+		}
+	}
+
+	var ops []jsonpatch.Operation
+	for _, e := range entries {
+		keyPath := jsonpatch.Pointer(section, e.Key)
+		old, exists := current[e.Key]
+		change := Change{Section: section, Key: e.Key, Old: old, New: e.Value}
+
+		switch e.Policy {
+		case PolicySet:
+			switch {
+			case !exists:
+				change.Action = ActionAdded
+				ops = append(ops, jsonpatch.Add(keyPath, e.Value))
+			case old != e.Value:
+				change.Action = ActionUpdated
+				ops = append(ops, jsonpatch.Replace(keyPath, e.Value))
+			default:
+				change.Action = ActionUnchanged
+			}
+		case PolicyKeepExisting:
+			if exists {
+				change.Action = ActionKept
+				change.New = old
+			} else {
+				change.Action = ActionAdded
+				ops = append(ops, jsonpatch.Add(keyPath, e.Value))
+			}
+		case PolicyRemove:
+			if !exists {
+				continue
+			}
+			change.Action = ActionRemoved
+			change.New = ""
+			ops = append(ops, jsonpatch.Remove(keyPath))
+		default:
+			return fmt.Errorf("policy inconnue %q pour %s", e.Policy, e.Key)
+		}
+
+		// une entrée suivante pour la même clé voit l'état après cette opération
+		if change.Action == ActionRemoved {
+			delete(current, e.Key)
+		} else {
+			current[e.Key] = change.New
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	return doc.Apply(ops...)
+}
+
+// Print affiche le rapport de fusion
+func (r *Report) Print() {
+	for _, c := range r.Changes {
+		switch c.Action {
+		case ActionAdded:
+			fmt.Printf("    + %s.%s = %q\n", c.Section, c.Key, c.New)
+		case ActionUpdated:
+			fmt.Printf("    ~ %s.%s: %q -> %q\n", c.Section, c.Key, c.Old, c.New)
+		case ActionKept:
+			fmt.Printf("    = %s.%s conservé: %q\n", c.Section, c.Key, c.Old)
+		case ActionRemoved:
+			fmt.Printf("    - %s.%s supprimé (%q)\n", c.Section, c.Key, c.Old)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GeneratedHeader en-tête des fichiers réécrits à chaque génération
+const GeneratedHeader = "// Code généré par starter client generate, ne pas modifier."
+
+// TypesContent types des définitions de l'api (types.ts)
+func TypesContent(c *Client) string {
+	var b strings.Builder
+	b.WriteString(GeneratedHeader + "\n")
+	if len(c.Types) == 0 {
+		b.WriteString("\nexport {};\n")
+	}
+	for _, t := range c.Types {
+		b.WriteString("\n")
+		if t.Doc != "" {
+			fmt.Fprintf(&b, "/** %s */\n", t.Doc)
+		}
+		if t.Kind == "interface" {
+			fmt.Fprintf(&b, "export interface %s %s\n", t.Name, t.Body)
+			continue
+		}
+		fmt.Fprintf(&b, "export type %s = %s;\n", t.Name, t.Body)
+	}
+	return b.String()
+}
+
+// ClientContent fonctions fetch typées de l'api (client.ts)
+// les réponses de l'api sont enveloppées par ginresponse ({ message, data, meta }), les fonctions retournent data
+func ClientContent(c *Client) string {
+	imports := map[string]bool{}
+	var functions strings.Builder
+	for _, fn := range c.Functions {
+		for _, name := range fn.Imports {
+			imports[name] = true
+		}
+		functions.WriteString(functionContent(fn))
+	}
+
+	var b strings.Builder
+	b.WriteString(GeneratedHeader + "\n\n")
+	b.WriteString("import { API_BASE_URL } from \"./config\";\n")
+	if len(imports) > 0 {
+		var names []string
+		for _, t := range c.Types {
+			if imports[t.Name] {
+				names = append(names, t.Name)
+			}
+		}
+		fmt.Fprintf(&b, "import type { %s } from \"./types\";\n", strings.Join(names, ", "))
+	}
+	b.WriteString(`
+/** Erreur renvoyée par l'api (statut HTTP hors 2xx) */
+export class ApiError extends Error {
+	constructor(
+		readonly status: number,
+		message: string,
+		readonly error: unknown,
+		readonly body: unknown,
+	) {
+		super(message);
+		this.name = "ApiError";
+	}
+}
+
+let baseUrl = API_BASE_URL;
+
+/** Change l'url de base de l'api (par défaut celle de config.ts) */
+export function setBaseUrl(url: string): void {
+	baseUrl = url;
+}
+
+type Primitive = string | number | boolean | null | undefined;
+
+interface RequestOptions {
+	query?: Record<string, Primitive | Primitive[]>;
+	headers?: Record<string, Primitive>;
+	body?: unknown;
+	form?: Record<string, Blob | Primitive>;
+}
+
+interface Envelope {
+	message?: string;
+	error?: unknown;
+	data?: unknown;
+}
+
+async function request<R>(method: string, path: string, options: RequestOptions, init?: RequestInit): Promise<R> {
+	const search = new URLSearchParams();
+	for (const [key, value] of Object.entries(options.query ?? {})) {
+		for (const item of Array.isArray(value) ? value : [value]) {
+			if (item !== undefined && item !== null) {
+				search.append(key, String(item));
+			}
+		}
+	}
+
+	const headers = new Headers(init?.headers);
+	headers.set("Accept", "application/json");
+	for (const [key, value] of Object.entries(options.headers ?? {})) {
+		if (value !== undefined && value !== null) {
+			headers.set(key, String(value));
+		}
+	}
+
+	let body: BodyInit | undefined;
+	if (options.form) {
+		const form = new FormData();
+		for (const [key, value] of Object.entries(options.form)) {
+			if (value instanceof Blob) {
+				form.append(key, value);
+			} else if (value !== undefined && value !== null) {
+				form.append(key, String(value));
+			}
+		}
+		body = form;
+	} else if (options.body !== undefined) {
+		headers.set("Content-Type", "application/json");
+		body = JSON.stringify(options.body);
+	}
+
+	const query = search.toString();
+	const response = await fetch(baseUrl.replace(/\/+$/, "") + path + (query ? "?" + query : ""), {
+		...init,
+		method,
+		headers,
+		body,
+	});
+
+	const text = await response.text();
+	let payload: unknown = undefined;
+	if (text) {
+		try {
+			payload = JSON.parse(text);
+		} catch {
+			payload = text;
+		}
+	}
+	const envelope = typeof payload === "object" && payload !== null ? (payload as Envelope) : undefined;
+
+	if (!response.ok) {
+		throw new ApiError(response.status, envelope?.message ?? response.statusText, envelope?.error, payload);
+	}
+	return (envelope && "data" in envelope ? envelope.data : payload) as R;
+}
+`)
+	b.WriteString(functions.String())
+	return b.String()
+}
+
+func functionContent(fn Function) string {
+	var args, query, headers, form []string
+	for _, p := range fn.PathParams {
+		args = append(args, fmt.Sprintf("%s: %s", p.Var, p.Type))
+	}
+	if fn.BodyType != "" {
+		switch {
+		case fn.BodyRequired:
+			args = append(args, "body: "+fn.BodyType)
+		case fn.ParamsOptional():
+			args = append(args, "body?: "+fn.BodyType)
+		default:
+			// un argument optionnel ne peut pas précéder params obligatoire
+			args = append(args, "body: "+fn.BodyType+" | undefined")
+		}
+	}
+	if len(fn.Params) > 0 {
+		var fields []string
+		for _, p := range fn.Params {
+			optional := "?"
+			if p.Required {
+				optional = ""
+			}
+			fields = append(fields, fmt.Sprintf("%s%s: %s", propertyKey(p.Name), optional, p.Type))
+			value := "params." + p.Name
+			if !identifierPattern.MatchString(p.Name) {
+				value = fmt.Sprintf("params[%q]", p.Name)
+			}
+			entry := fmt.Sprintf("%s: %s", propertyKey(p.Name), value)
+			switch p.In {
+			case "query":
+				query = append(query, entry)
+			case "header":
+				headers = append(headers, entry)
+			case "formData":
+				form = append(form, entry)
+			}
+		}
+		param := fmt.Sprintf("params: { %s }", strings.Join(fields, "; "))
+		if fn.ParamsOptional() {
+			param += " = {}"
+		}
+		args = append(args, param)
+	}
+	args = append(args, "init?: RequestInit")
+
+	var options []string
+	if len(query) > 0 {
+		options = append(options, fmt.Sprintf("query: { %s }", strings.Join(query, ", ")))
+	}
+	if len(headers) > 0 {
+		options = append(options, fmt.Sprintf("headers: { %s }", strings.Join(headers, ", ")))
+	}
+	if len(form) > 0 {
+		options = append(options, fmt.Sprintf("form: { %s }", strings.Join(form, ", ")))
+	}
+	if fn.BodyType != "" {
+		options = append(options, "body")
+	}
+
+	optionsLiteral := "{}"
+	if len(options) > 0 {
+		optionsLiteral = "{ " + strings.Join(options, ", ") + " }"
+	}
+
+	path := strconv.Quote(fn.Path)
+	if len(fn.PathParams) > 0 {
+		path = fn.Path
+		for _, p := range fn.PathParams {
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", "${encodeURIComponent(String("+p.Var+"))}")
+		}
+		path = "`" + path + "`"
+	}
+
+	doc := fmt.Sprintf("%s %s", fn.Method, fn.Path)
+	if fn.Summary != "" {
+		doc = fmt.Sprintf("%s (%s)", fn.Summary, doc)
+	}
+	return fmt.Sprintf(`
+/** %s */
+export function %s(%s): Promise<%s> {
+	return request<%s>(%q, %s, %s, init);
+}
+`, doc, fn.Name, strings.Join(args, ", "), fn.ReturnType, fn.ReturnType, fn.Method, path, optionsLiteral)
+}
+
+// ConfigContent url de base de l'api (config.ts)
+// Astro lit PUBLIC_API_URL du .env du front au build, Angular reçoit la valeur du .env lue à la génération
+func ConfigContent(framework, baseURL string) string {
+	if framework == "angular" {
+		return fmt.Sprintf(`%s
+
+/** url de base de l'api, PUBLIC_API_URL du .env du front au moment de la génération */
+export const API_BASE_URL = %s;
+`, GeneratedHeader, strconv.Quote(baseURL))
+	}
+	return fmt.Sprintf(`%s
+
+/** url de base de l'api, PUBLIC_API_URL du .env du front */
+export const API_BASE_URL: string = import.meta.env.PUBLIC_API_URL ?? "";
+`, GeneratedHeader)
+}
@@ -0,0 +1,370 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/nsevendev/starter/internal/tools"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Client modèle TypeScript d'un document Swagger, utilisé par les fichiers générés
+type Client struct {
+	Title     string
+	Types     []TypeDecl
+	Functions []Function
+	names     map[string]string // nom de définition swag => nom TypeScript
+}
+
+// TypeDecl type TypeScript généré à partir d'une définition
+type TypeDecl struct {
+	Name string
+	Doc  string
+	Body string // ex: "{\n\tid: number;\n}" ou "Pet[]"
+	Kind string // "interface" ou "type"
+}
+
+// Param paramètre d'une fonction du client
+type Param struct {
+	Name     string // nom dans le document
+	In       string // path, query, header ou formData
+	Var      string // argument TypeScript (paramètres de path)
+	Type     string
+	Required bool
+}
+
+// Function fonction du client pour une opération
+type Function struct {
+	Name         string
+	Method       string
+	Path         string
+	Summary      string
+	PathParams   []Param
+	Params       []Param // query, header et formData, regroupés dans l'argument params
+	BodyType     string
+	BodyRequired bool
+	ReturnType   string
+	Imports      []string
+}
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// reservedWords mots réservés TypeScript qui ne peuvent pas nommer un argument
+var reservedWords = []string{
+	"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do", "else", "enum",
+	"export", "extends", "false", "finally", "for", "function", "if", "import", "in", "instanceof", "new", "null",
+	"return", "super", "switch", "this", "throw", "true", "try", "typeof", "var", "void", "while", "with",
+	"params", "body", "init",
+}
+
+// Build construit le modèle TypeScript d'un document
+func Build(doc *Document) (*Client, error) {
+	c := &Client{Title: doc.Info.Title, names: typeNames(doc.Definitions)}
+
+	definitions := make([]string, 0, len(doc.Definitions))
+	for name := range doc.Definitions {
+		definitions = append(definitions, name)
+	}
+	sort.Strings(definitions)
+	for _, name := range definitions {
+		schema := doc.Definitions[name]
+		decl := TypeDecl{Name: c.names[name], Doc: oneLine(schema.Description), Kind: "type"}
+		body, err := c.tsType(schema, nil, "")
+		if err != nil {
+			return nil, fmt.Errorf("définition %s: %w", name, err)
+		}
+		if len(schema.Properties) > 0 && len(schema.AllOf) == 0 {
+			decl.Kind = "interface"
+		}
+		decl.Body = body
+		c.Types = append(c.Types, decl)
+	}
+
+	seen := map[string]string{}
+	for _, route := range doc.Routes() {
+		fn, err := c.function(route)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+		if previous, ok := seen[fn.Name]; ok {
+			return nil, fmt.Errorf("%s %s: fonction %s déjà utilisée par %s", route.Method, route.Path, fn.Name, previous)
+		}
+		seen[fn.Name] = route.Method + " " + route.Path
+		c.Functions = append(c.Functions, fn)
+	}
+	return c, nil
+}
+
+// typeNames nom TypeScript des définitions: le nom sans le package Go ("apicontroller.Pet" => "Pet", voir shortName),
+// ou le nom complet si deux définitions ont le même nom court
+func typeNames(definitions map[string]*Schema) map[string]string {
+	count := map[string]int{}
+	for name := range definitions {
+		count[shortName(name)]++
+	}
+	names := map[string]string{}
+	for name := range definitions {
+		if count[shortName(name)] > 1 {
+			names[name] = tools.PascalCase(tools.SplitWords(name))
+			continue
+		}
+		names[name] = shortName(name)
+	}
+	return names
+}
+
+// shortName nom d'une définition sans le package Go, pour un type générique swag (<package>.<Type>-<package>_<Argument>)
+// le package du type générique est conservé et les arguments perdent le leur:
+// "proj_api_internal_domain_pagination.Result-proj_api_internal_domain_nseven_Nseven" => "PaginationResultNseven"
+func shortName(name string) string {
+	base, args, generic := strings.Cut(name, "-")
+	dot := strings.LastIndex(base, ".")
+	s := base[dot+1:]
+	if generic {
+		pkg := base[:max(dot, 0)]
+		s = tools.PascalCase(tools.SplitWords(pkg[strings.LastIndex(pkg, "_")+1:])) + s
+		for _, arg := range strings.Split(args, "-") {
+			s += arg[strings.LastIndex(arg, "_")+1:]
+		}
+	}
+	if !identifierPattern.MatchString(s) {
+		return tools.PascalCase(tools.SplitWords(name))
+	}
+	return s
+}
+
+// tsType type TypeScript d'un schéma, refs reçoit les types référencés
+func (c *Client) tsType(schema *Schema, refs map[string]bool, indent string) (string, error) {
+	if schema == nil {
+		return "unknown", nil
+	}
+	if schema.Ref != "" {
+		definition, err := DefinitionName(schema.Ref)
+		if err != nil {
+			return "", err
+		}
+		name, ok := c.names[definition]
+		if !ok {
+			return "", fmt.Errorf("définition %s absente", definition)
+		}
+		if refs != nil {
+			refs[name] = true
+		}
+		return name, nil
+	}
+	if len(schema.AllOf) > 0 {
+		var parts []string
+		for _, part := range schema.AllOf {
+			t, err := c.tsType(part, refs, indent)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, t)
+		}
+		return strings.Join(parts, " & "), nil
+	}
+	if len(schema.Enum) > 0 {
+		return enumType(schema.Enum), nil
+	}
+
+	switch schema.Type {
+	case "string":
+		return "string", nil
+	case "integer", "number":
+		return "number", nil
+	case "boolean":
+		return "boolean", nil
+	case "file":
+		return "Blob", nil
+	case "array":
+		item, err := c.tsType(schema.Items, refs, indent)
+		if err != nil {
+			return "", err
+		}
+		if strings.ContainsAny(item, "|&") {
+			item = "(" + item + ")"
+		}
+		return item + "[]", nil
+	}
+
+	if len(schema.Properties) == 0 {
+		if extra := schema.Extra(); extra != nil {
+			value, err := c.tsType(extra, refs, indent)
+			if err != nil {
+				return "", err
+			}
+			return "Record<string, " + value + ">", nil
+		}
+		if schema.Type == "object" {
+			return "Record<string, unknown>", nil
+		}
+		return "unknown", nil
+	}
+
+	required := map[string]bool{}
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(schema.Properties))
+	for prop := range schema.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, prop := range props {
+		propSchema := schema.Properties[prop]
+		t, err := c.tsType(propSchema, refs, indent+"\t")
+		if err != nil {
+			return "", fmt.Errorf("propriété %s: %w", prop, err)
+		}
+		if doc := oneLine(propSchema.Description); doc != "" {
+			fmt.Fprintf(&b, "%s\t/** %s */\n", indent, doc)
+		}
+		optional := "?"
+		if required[prop] {
+			optional = ""
+		}
+		fmt.Fprintf(&b, "%s\t%s%s: %s;\n", indent, propertyKey(prop), optional, t)
+	}
+	b.WriteString(indent + "}")
+	return b.String(), nil
+}
+
+func (c *Client) function(route Route) (Function, error) {
+	op := route.Operation
+	fn := Function{
+		Name:    functionName(op.OperationID, route.Method, route.Path),
+		Method:  route.Method,
+		Path:    route.Path,
+		Summary: oneLine(op.Summary),
+	}
+	refs := map[string]bool{}
+
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "body":
+			t, err := c.tsType(p.Schema, refs, "")
+			if err != nil {
+				return Function{}, fmt.Errorf("paramètre %s: %w", p.Name, err)
+			}
+			fn.BodyType = t
+			fn.BodyRequired = p.Required
+		case "path", "query", "header", "formData":
+			schema := &Schema{Type: p.Type, Items: p.Items, Enum: p.Enum}
+			t, err := c.tsType(schema, refs, "")
+			if err != nil {
+				return Function{}, fmt.Errorf("paramètre %s: %w", p.Name, err)
+			}
+			param := Param{Name: p.Name, In: p.In, Type: t, Required: p.Required}
+			if p.In == "path" {
+				param.Required = true
+				param.Var = varName(p.Name)
+				fn.PathParams = append(fn.PathParams, param)
+				continue
+			}
+			fn.Params = append(fn.Params, param)
+		default:
+			return Function{}, fmt.Errorf("paramètre %s: emplacement %q non supporté", p.Name, p.In)
+		}
+	}
+	if fn.BodyType != "" && fn.Multipart() {
+		return Function{}, fmt.Errorf("corps JSON et formData dans la même opération non supportés")
+	}
+
+	fn.ReturnType = "void"
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if schema := op.Responses[code].Schema; schema != nil {
+			t, err := c.tsType(schema, refs, "")
+			if err != nil {
+				return Function{}, fmt.Errorf("réponse %s: %w", code, err)
+			}
+			fn.ReturnType = t
+		}
+		break
+	}
+
+	for name := range refs {
+		fn.Imports = append(fn.Imports, name)
+	}
+	sort.Strings(fn.Imports)
+	return fn, nil
+}
+
+// Multipart indique que l'opération attend un formulaire multipart (paramètres formData)
+func (f Function) Multipart() bool {
+	for _, p := range f.Params {
+		if p.In == "formData" {
+			return true
+		}
+	}
+	return false
+}
+
+// ParamsOptional indique que tous les paramètres regroupés dans params sont optionnels
+func (f Function) ParamsOptional() bool {
+	for _, p := range f.Params {
+		if p.Required {
+			return false
+		}
+	}
+	return true
+}
+
+// functionName nom de la fonction: operationId (@ID swag) ou méthode + chemin, ex: GET /pets/{petId} => getPetsByPetID
+func functionName(operationID, method, path string) string {
+	if words := tools.SplitWords(operationID); len(words) > 0 {
+		return tools.CamelCase(words)
+	}
+	words := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			words = append(words, "by")
+		}
+		words = append(words, tools.SplitWords(segment)...)
+	}
+	return tools.CamelCase(words)
+}
+
+func varName(name string) string {
+	v := tools.CamelCase(tools.SplitWords(name))
+	for _, reserved := range reservedWords {
+		if v == reserved {
+			return v + "Value"
+		}
+	}
+	return v
+}
+
+func propertyKey(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+func enumType(values []any) string {
+	var literals []string
+	for _, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		literals = append(literals, string(data))
+	}
+	return strings.Join(literals, " | ")
+}
+
+func oneLine(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "*/", "* /")
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Document sous-ensemble d'un document Swagger 2.0 (docs/swagger.json généré par swag)
+type Document struct {
+	Swagger     string                           `json:"swagger"`
+	Info        Info                             `json:"info"`
+	BasePath    string                           `json:"basePath"`
+	Paths       map[string]map[string]*Operation `json:"paths"`
+	Definitions map[string]*Schema               `json:"definitions"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description"`
+	Tags        []string            `json:"tags"`
+	Parameters  []Parameter         `json:"parameters"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Type        string  `json:"type"`
+	Items       *Schema `json:"items"`
+	Enum        []any   `json:"enum"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *Schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Enum                 []any              `json:"enum"`
+	AllOf                []*Schema          `json:"allOf"`
+}
+
+// Route opération d'un chemin, dans l'ordre des chemins puis des méthodes
+type Route struct {
+	Method    string
+	Path      string
+	Operation *Operation
+}
+
+// Load lit un document Swagger 2.0
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", path, err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse de %s: %w", path, err)
+	}
+	if doc.Swagger != "2.0" {
+		return nil, fmt.Errorf("%s: seuls les documents Swagger 2.0 générés par swag sont supportés (swagger: %q)", path, doc.Swagger)
+	}
+	return &doc, nil
+}
+
+// Routes opérations du document triées par chemin puis par méthode
+func (d *Document) Routes() []Route {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var routes []Route
+	for _, path := range paths {
+		for _, method := range []string{"get", "post", "put", "patch", "delete"} {
+			if op, ok := d.Paths[path][method]; ok && op != nil {
+				routes = append(routes, Route{Method: strings.ToUpper(method), Path: path, Operation: op})
+			}
+		}
+	}
+	return routes
+}
+
+// Extra schéma des valeurs d'un objet libre (additionalProperties), nil si non typé
+func (s *Schema) Extra() *Schema {
+	if len(s.AdditionalProperties) == 0 {
+		return nil
+	}
+	var extra Schema
+	if err := json.Unmarshal(s.AdditionalProperties, &extra); err != nil {
+		// additionalProperties: true
+		return nil
+	}
+	return &extra
+}
+
+// DefinitionName nom de la définition référencée par "#/definitions/<nom>"
+func DefinitionName(ref string) (string, error) {
+	const prefix = "#/definitions/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("référence %q non supportée (seul %s<nom> est supporté)", ref, prefix)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
@@ -0,0 +1,361 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/tools"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// GeneratedHeader en-tête des fichiers réécrits à chaque génération
+const GeneratedHeader = "// Code generated by starter api generate. DO NOT EDIT."
+
+// failureType enveloppe {data, error, message, meta} des réponses de ctx, résolue par swag init --pd (api.dockerfile)
+const failureType = "ginresponse.JsonFormatterSwag"
+
+// ModelsContent types de requête et de réponse (Models.gen.go)
+func ModelsContent(api *Api) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\npackage %s\n\n", GeneratedHeader, api.Package)
+	if api.usesTime {
+		b.WriteString("import \"time\"\n\n")
+	}
+	for _, t := range api.Types {
+		doc := t.Doc
+		if doc == "" {
+			doc = "schéma " + t.Name
+		}
+		fmt.Fprintf(&b, "// %s %s\n", t.Name, oneLine(doc))
+		if t.Alias != "" {
+			fmt.Fprintf(&b, "type %s %s\n\n", t.Name, t.Alias)
+			continue
+		}
+		fmt.Fprintf(&b, "type %s struct {\n", t.Name)
+		for _, f := range t.Fields {
+			if f.Doc != "" {
+				fmt.Fprintf(&b, "\t// %s %s\n", f.Name, f.Doc)
+			}
+			tag := fmt.Sprintf("json:%q", f.JSON)
+			if f.Binding != "" {
+				tag += fmt.Sprintf(" binding:%q", f.Binding)
+			}
+			fmt.Fprintf(&b, "\t%s %s `%s`\n", f.Name, f.Type, tag)
+		}
+		b.WriteString("}\n\n")
+	}
+	return gofmt(b.String())
+}
+
+// ServerContent interface des handlers, enregistrement des routes et wrappers (Server.gen.go)
+// les wrappers lisent et valident paramètres et corps avant d'appeler le handler
+func ServerContent(api *Api) string {
+	var iface, routes, wrappers strings.Builder
+	for _, op := range api.Endpoints {
+		fmt.Fprintf(&iface, "\t// %s %s %s\n", op.Name, op.Method, op.Path)
+		if op.Summary != "" {
+			fmt.Fprintf(&iface, "\t// %s\n", op.Summary)
+		}
+		fmt.Fprintf(&iface, "\t%s(%s)\n", op.Name, op.Signature())
+		fmt.Fprintf(&routes, "\tr.Handle(%q, %q, w.%s%s)\n", op.Method, op.RouterPath, op.Name, op.RouteOptions())
+		wrappers.WriteString(wrapperContent(op))
+	}
+
+	return gofmt(fmt.Sprintf(`%[1]s
+
+package %[2]s
+
+import (
+	"bytes"
+	"errors"
+	"github.com/go-playground/validator/v10"
+	"%[3]s/internal/application/gateway/httpgateway"
+	"io"
+	"strconv"
+)
+
+// ServerInterface handlers des opérations de la spec
+type ServerInterface interface {
+%[4]s}
+
+// Controller doit implémenter toutes les opérations de la spec
+var _ ServerInterface = (*Controller)(nil)
+
+// RegisterHandlers enregistre les routes de la spec sur le router
+func RegisterHandlers(r httpgateway.Router, si ServerInterface) {
+	w := &serverWrapper{handler: si}
+%[5]s}
+
+// serverWrapper lit les paramètres et le corps des requêtes avant d'appeler les handlers
+type serverWrapper struct {
+	handler ServerInterface
+}
+%[6]s
+// bindParam convertit un paramètre, nil s'il est absent et optionnel
+// ok vaut false si la réponse d'erreur a été envoyée
+func bindParam[T any](ctx httpgateway.Context, in, name, raw string, required bool, parse func(string) (T, error)) (value *T, ok bool) {
+	if raw == "" {
+		if required {
+			ctx.BadRequest("Paramètre "+in+" "+name+" obligatoire", nil)
+			return nil, false
+		}
+		return nil, true
+	}
+	v, err := parse(raw)
+	if err != nil {
+		ctx.BadRequest("Paramètre "+in+" "+name+" invalide", err.Error())
+		return nil, false
+	}
+	return &v, true
+}
+
+// paramValidator vérifie les paramètres avec les règles binding de leur schéma
+var paramValidator = validator.New()
+
+// checkParam valide un paramètre selon les règles binding de son schéma (minLength, maximum, enum...),
+// répond 422 comme ctx.BindJSON, ok vaut false si la réponse d'erreur a été envoyée
+func checkParam(ctx httpgateway.Context, in, name string, value any, rules string) (ok bool) {
+	err := paramValidator.Var(value, rules)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		if err != nil {
+			ctx.InternalServerError("Validation du paramètre "+in+" "+name+" impossible", err.Error())
+			return false
+		}
+		return true
+	}
+
+	responses := make([]httpgateway.ErrorResponse, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		responses[i] = httpgateway.ErrorResponse{Message: "règle " + rule + " non respectée", Type: "validation", Field: name, Detail: rule}
+	}
+	ctx.UnprocessableEntity("Paramètre "+in+" "+name+" invalide", responses)
+	return false
+}
+
+// decodeBody décode et valide le corps JSON de la requête (ctx.BindJSON)
+// un corps absent est accepté s'il est optionnel, ok vaut false si la réponse d'erreur a été envoyée
+func decodeBody(ctx httpgateway.Context, body any, required bool) (ok bool) {
+	if !required {
+		data, err := io.ReadAll(ctx.Request().Body)
+		if err != nil {
+			ctx.BadRequest("Corps de la requête illisible", err.Error())
+			return false
+		}
+		if len(bytes.TrimSpace(data)) == 0 {
+			return true
+		}
+		ctx.Request().Body = io.NopCloser(bytes.NewReader(data))
+	}
+	return ctx.BindJSON(body)
+}
+
+func parseString(s string) (string, error) { return s, nil }
+
+func parseInt(s string) (int, error) { return strconv.Atoi(s) }
+
+func parseInt32(s string) (int32, error) {
+	v, err := strconv.ParseInt(s, 10, 32)
+	return int32(v), err
+}
+
+func parseInt64(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
+
+func parseFloat32(s string) (float32, error) {
+	v, err := strconv.ParseFloat(s, 32)
+	return float32(v), err
+}
+
+func parseFloat64(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
+
+func parseBool(s string) (bool, error) { return strconv.ParseBool(s) }
+`, GeneratedHeader, api.Package, api.ModuleName, iface.String(), routes.String(), wrappers.String()))
+}
+
+func wrapperContent(op *Endpoint) string {
+	var b strings.Builder
+	summary := op.Summary
+	if summary == "" {
+		summary = op.Name
+	}
+	fmt.Fprintf(&b, "\n// %s %s %s\n", op.Name, op.Method, op.Path)
+	fmt.Fprintf(&b, "// @Summary %s\n", summary)
+	id := op.OperationID
+	if id == "" {
+		id = tools.CamelCase(tools.SplitWords(op.Name))
+	}
+	fmt.Fprintf(&b, "// @ID %s\n", id)
+	if op.Description != "" {
+		fmt.Fprintf(&b, "// @Description %s\n", op.Description)
+	}
+	if len(op.Tags) > 0 {
+		fmt.Fprintf(&b, "// @Tags %s\n", strings.Join(op.Tags, ","))
+	}
+	if op.BodyType != "" {
+		b.WriteString("// @Accept json\n")
+	}
+	b.WriteString("// @Produce json\n")
+	if op.Secured {
+		b.WriteString("// @Security BearerAuth\n")
+	}
+	for _, p := range append(append([]Param{}, op.PathParams...), op.Params...) {
+		fmt.Fprintf(&b, "// @Param %s %s %s %t %q\n", p.Name, p.In, swagType(p.Type), p.Required, paramDoc(p))
+	}
+	if op.BodyType != "" {
+		bodyDoc := op.BodyDoc
+		if bodyDoc == "" {
+			bodyDoc = "corps de la requête"
+		}
+		fmt.Fprintf(&b, "// @Param body body %s %t %q\n", op.BodyType, op.BodyRequired, bodyDoc)
+	}
+	if op.SuccessType != "" {
+		fmt.Fprintf(&b, "// @Success %s %s\n", op.Success, op.SuccessType)
+	} else {
+		fmt.Fprintf(&b, "// @Success %s\n", op.Success)
+	}
+	for _, code := range failureCodes(op) {
+		fmt.Fprintf(&b, "// @Failure %s {object} %s\n", code, failureType)
+	}
+	fmt.Fprintf(&b, "// @Router %s [%s]\n", op.Path, strings.ToLower(op.Method))
+	fmt.Fprintf(&b, "func (w *serverWrapper) %s(ctx httpgateway.Context) {\n", op.Name)
+
+	var args []string
+	for _, p := range op.PathParams {
+		fmt.Fprintf(&b, "\t%s, ok := bindParam(ctx, %q, %q, ctx.Param(%q), true, %s)\n\tif !ok {\n\t\treturn\n\t}\n", p.Var, p.In, p.Name, p.Name, parseFunc(p.Type))
+		if p.Rules != "" {
+			fmt.Fprintf(&b, "\tif !checkParam(ctx, %q, %q, *%s, %q) {\n\t\treturn\n\t}\n", p.In, p.Name, p.Var, p.Rules)
+		}
+		args = append(args, "*"+p.Var)
+	}
+	if op.ParamsType != "" {
+		fmt.Fprintf(&b, "\tvar params %s\n", op.ParamsType)
+		for _, p := range op.Params {
+			source := "Query"
+			if p.In == "header" {
+				source = "Header"
+			}
+			assign := "v"
+			if p.Required {
+				assign = "*v"
+			}
+			fmt.Fprintf(&b, "\tif v, ok := bindParam(ctx, %q, %q, ctx.%s(%q), %t, %s); !ok {\n\t\treturn\n\t} else if v != nil {\n", p.In, p.Name, source, p.Name, p.Required, parseFunc(p.Type))
+			if p.Rules != "" {
+				fmt.Fprintf(&b, "\t\tif !checkParam(ctx, %q, %q, *v, %q) {\n\t\t\treturn\n\t\t}\n", p.In, p.Name, p.Rules)
+			}
+			fmt.Fprintf(&b, "\t\tparams.%s = %s\n\t}\n", p.GoName, assign)
+		}
+		args = append(args, "params")
+	}
+	if op.BodyType != "" {
+		fmt.Fprintf(&b, "\tvar body %s\n\tif !decodeBody(ctx, &body, %t) {\n\t\treturn\n\t}\n", op.BodyType, op.BodyRequired)
+		args = append(args, "body")
+	}
+	fmt.Fprintf(&b, "\tw.handler.%s(%s)\n}\n", op.Name, strings.Join(append([]string{"ctx"}, args...), ", "))
+	return b.String()
+}
+
+// ControllerContent controller qui implémente ServerInterface (créé une seule fois)
+func ControllerContent(api *Api) string {
+	return fmt.Sprintf(`package %[2]s
+
+import (
+	"%[1]s/internal/application/gateway/httpgateway"
+)
+
+// Controller implémente ServerInterface (Server.gen.go), un fichier par opération
+type Controller struct{}
+
+// New crée une nouvelle instance du controller
+func New() *Controller {
+	return &Controller{}
+}
+
+// RegisterRoutes enregistre les routes de la spec
+func (c *Controller) RegisterRoutes(r httpgateway.Router) {
+	RegisterHandlers(r, c)
+}
+`, api.ModuleName, api.Package)
+}
+
+// HandlerContent handler d'une opération à implémenter (créé une seule fois, jamais réécrit)
+func HandlerContent(api *Api, op *Endpoint) string {
+	doc := ""
+	if op.Summary != "" {
+		doc = "// " + op.Summary + "\n"
+	}
+	return gofmt(fmt.Sprintf(`package %[2]s
+
+import (
+	"%[1]s/internal/application/gateway/httpgateway"
+)
+
+// %[3]s %[4]s %[5]s
+%[6]sfunc (c *Controller) %[3]s(%[7]s) {
+	ctx.InternalServerError("%[3]s non implémenté", nil)
+}
+`, api.ModuleName, api.Package, op.Name, op.Method, op.Path, doc, op.Signature()))
+}
+
+// failureCodes codes d'erreur de la spec et ceux renvoyés par le wrapper
+func failureCodes(op *Endpoint) []string {
+	codes := append([]string{}, op.Failures...)
+	add := func(code string) {
+		for _, c := range codes {
+			if c == code {
+				return
+			}
+		}
+		codes = append(codes, code)
+	}
+	if len(op.PathParams) > 0 || len(op.Params) > 0 || op.BodyType != "" {
+		add("400")
+	}
+	if op.BodyIsStruct {
+		add("422")
+	}
+	if op.Secured {
+		add("401")
+	}
+	if len(op.Scopes) > 0 {
+		add("403")
+	}
+	add("500")
+	sort.Strings(codes)
+	return codes
+}
+
+func parseFunc(goType string) string {
+	return "parse" + strings.ToUpper(goType[:1]) + goType[1:]
+}
+
+func swagType(goType string) string {
+	switch {
+	case goType == "bool":
+		return "boolean"
+	case strings.HasPrefix(goType, "int"):
+		return "integer"
+	case strings.HasPrefix(goType, "float"):
+		return "number"
+	}
+	return "string"
+}
+
+func paramDoc(p Param) string {
+	if p.Doc != "" {
+		return p.Doc
+	}
+	return p.Name
+}
+
+// gofmt formate le code généré, le code est retourné tel quel s'il ne peut pas être formaté
+func gofmt(src string) string {
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return string(out)
+}
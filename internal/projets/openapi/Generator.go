@@ -0,0 +1,558 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/tools"
+	"go/token"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Api modèle Go d'une spec OpenAPI, utilisé par les fichiers générés
+type Api struct {
+	ModuleName string
+	Package    string
+	Title      string
+	Types      []*TypeDecl
+	Endpoints  []*Endpoint
+	usesTime   bool
+	structs    map[string]bool
+	schemas    map[string]*Schema // schémas nommés, pour résoudre les $ref de allOf
+}
+
+// TypeDecl type Go généré (struct ou type nommé)
+type TypeDecl struct {
+	Name   string
+	Doc    string
+	Fields []Field // struct
+	Alias  string  // type nommé non struct, ex: "[]Pet"
+}
+
+// Field champ d'un type généré
+type Field struct {
+	Name    string
+	Type    string
+	JSON    string
+	Binding string
+	Doc     string
+}
+
+// Param paramètre d'une opération (path, query ou header)
+type Param struct {
+	Name     string // nom dans la spec
+	In       string
+	GoName   string // champ du struct de paramètres
+	Var      string // argument du handler (paramètres de path)
+	Type     string // type Go scalaire
+	Required bool
+	Doc      string
+	Rules    string // règles binding du schéma, vérifiées par checkParam, ex: "gte=1,lte=100"
+}
+
+// Endpoint opération de la spec et signature de son handler
+type Endpoint struct {
+	Name         string
+	OperationID  string // operationId de la spec, repris tel quel dans @ID (vide si absent)
+	Method       string
+	Path         string // chemin OpenAPI, ex: /pets/{petId}
+	RouterPath   string // chemin du router, ex: /pets/:petId
+	Summary      string
+	Description  string
+	Tags         []string
+	PathParams   []Param
+	Params       []Param // query et header, regroupés dans <Name>Params
+	ParamsType   string
+	BodyType     string
+	BodyDoc      string
+	BodyRequired bool
+	BodyIsStruct bool
+	Success      string // code de la réponse 2xx
+	SuccessType  string // ex: "{object} Pet", "{array} Pet"
+	Failures     []string
+	Secured      bool     // réservée aux utilisateurs authentifiés (security de la spec)
+	Scopes       []string // permissions requises, scopes de la sécurité
+}
+
+// Build construit le modèle Go d'une spec
+func Build(doc *Document, moduleName, pkg string) (*Api, error) {
+	api := &Api{ModuleName: moduleName, Package: pkg, Title: doc.Info.Title, structs: map[string]bool{}, schemas: doc.Components.Schemas}
+	types := map[string]*TypeDecl{}
+
+	// schémas nommés
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := api.declare(types, goName(name), doc.Components.Schemas[name]); err != nil {
+			return nil, fmt.Errorf("schéma %s: %w", name, err)
+		}
+	}
+
+	seen := map[string]string{}
+	for _, route := range doc.Routes() {
+		op, err := api.endpoint(types, route)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+		if previous, ok := seen[op.Name]; ok {
+			return nil, fmt.Errorf("%s %s: operationId %s déjà utilisé par %s", route.Method, route.Path, op.Name, previous)
+		}
+		seen[op.Name] = route.Method + " " + route.Path
+		api.Endpoints = append(api.Endpoints, op)
+	}
+
+	typeNames := make([]string, 0, len(types))
+	for name := range types {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		api.Types = append(api.Types, types[name])
+	}
+	return api, nil
+}
+
+// declare ajoute un type nommé pour un schéma
+func (a *Api) declare(types map[string]*TypeDecl, name string, schema *Schema) error {
+	if _, ok := types[name]; ok {
+		return fmt.Errorf("type %s déjà déclaré", name)
+	}
+	schema, err := a.flatten(schema)
+	if err != nil {
+		return err
+	}
+	decl := &TypeDecl{Name: name, Doc: schema.Description}
+	types[name] = decl
+
+	if !isObject(schema) || schema.Ref != "" {
+		alias, err := a.goType(types, schema, name+"Item")
+		if err != nil {
+			return err
+		}
+		decl.Alias = alias
+		return nil
+	}
+
+	a.structs[name] = true
+	required := map[string]bool{}
+	for _, r := range schema.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(schema.Properties))
+	for prop := range schema.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	for _, prop := range props {
+		propSchema := schema.Properties[prop]
+		if propSchema == nil {
+			return fmt.Errorf("propriété %s sans schéma", prop)
+		}
+		fieldName := goName(prop)
+		goType, err := a.goType(types, propSchema, name+fieldName)
+		if err != nil {
+			return fmt.Errorf("propriété %s: %w", prop, err)
+		}
+		decl.Fields = append(decl.Fields, a.field(prop, fieldName, goType, propSchema, required[prop]))
+	}
+	return nil
+}
+
+// field champ d'un struct: obligatoire en valeur, optionnel en pointeur (sauf slice, map et any) avec omitempty
+func (a *Api) field(prop, name, goType string, schema *Schema, required bool) Field {
+	f := Field{Name: name, Type: goType, JSON: prop, Doc: schema.Description}
+	nilable := strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") || goType == "any"
+
+	var rules []string
+	if required {
+		// un nombre ou un booléen à zéro est une valeur valide, required ne s'applique qu'aux autres types
+		if !isNumberOrBool(goType) {
+			rules = append(rules, "required")
+		}
+	} else {
+		f.JSON += ",omitempty"
+		if !nilable {
+			f.Type = "*" + goType
+		}
+	}
+
+	constraints := bindingRules(schema)
+	if len(constraints) > 0 && !required {
+		rules = append(rules, "omitempty")
+	}
+	f.Binding = strings.Join(append(rules, constraints...), ",")
+	return f
+}
+
+// goType type Go d'un schéma, hint nomme les objets inline
+func (a *Api) goType(types map[string]*TypeDecl, schema *Schema, hint string) (string, error) {
+	if schema == nil {
+		return "any", nil
+	}
+	schema, err := a.flatten(schema)
+	if err != nil {
+		return "", err
+	}
+	if schema.Ref != "" {
+		name, err := SchemaName(schema.Ref)
+		if err != nil {
+			return "", err
+		}
+		return goName(name), nil
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			a.usesTime = true
+			return "time.Time", nil
+		}
+		return "string", nil
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			return "int32", nil
+		case "int64":
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		item, err := a.goType(types, schema.Items, hint+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	}
+
+	if isObject(schema) {
+		if len(schema.Properties) == 0 {
+			extra, err := schema.Extra()
+			if err != nil || extra == nil {
+				return "map[string]any", err
+			}
+			value, err := a.goType(types, extra, hint+"Value")
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + value, nil
+		}
+		if err := a.declare(types, hint, schema); err != nil {
+			return "", err
+		}
+		return hint, nil
+	}
+	return "any", nil
+}
+
+// flatten fusionne les schémas de allOf (propriétés et required) en un seul objet,
+// oneOf et anyOf n'ont pas d'équivalent Go sans perdre le typage et sont refusés
+func (a *Api) flatten(schema *Schema) (*Schema, error) {
+	switch {
+	case len(schema.OneOf) > 0:
+		return nil, fmt.Errorf("oneOf non supporté")
+	case len(schema.AnyOf) > 0:
+		return nil, fmt.Errorf("anyOf non supporté")
+	case len(schema.AllOf) == 0:
+		return schema, nil
+	}
+
+	merged := &Schema{Type: "object", Description: schema.Description, Properties: map[string]*Schema{}}
+	parts := append(append([]*Schema{}, schema.AllOf...), &Schema{Properties: schema.Properties, Required: schema.Required})
+	for _, part := range parts {
+		if part == nil {
+			continue
+		}
+		if part.Ref != "" {
+			name, err := SchemaName(part.Ref)
+			if err != nil {
+				return nil, err
+			}
+			resolved, ok := a.schemas[name]
+			if !ok {
+				return nil, fmt.Errorf("allOf: schéma %s introuvable", part.Ref)
+			}
+			part = resolved
+		}
+		part, err := a.flatten(part)
+		if err != nil {
+			return nil, fmt.Errorf("allOf: %w", err)
+		}
+		if part.Type != "" && part.Type != "object" {
+			return nil, fmt.Errorf("allOf: schéma de type %s, objets attendus", part.Type)
+		}
+		if merged.Description == "" {
+			merged.Description = part.Description
+		}
+		for prop, propSchema := range part.Properties {
+			merged.Properties[prop] = propSchema
+		}
+		for _, r := range part.Required {
+			if !slices.Contains(merged.Required, r) {
+				merged.Required = append(merged.Required, r)
+			}
+		}
+	}
+	return merged, nil
+}
+
+func (a *Api) endpoint(types map[string]*TypeDecl, route Route) (*Endpoint, error) {
+	spec := route.Operation
+	name := goName(spec.OperationID)
+	if name == "" {
+		name = goName(strings.ToLower(route.Method) + " " + strings.NewReplacer("{", "", "}", "").Replace(route.Path))
+	}
+
+	op := &Endpoint{
+		Name:        name,
+		OperationID: spec.OperationID,
+		Method:      route.Method,
+		Path:        route.Path,
+		RouterPath:  routerPath(route.Path),
+		Summary:     oneLine(spec.Summary),
+		Description: oneLine(spec.Description),
+		Tags:        spec.Tags,
+	}
+	op.Secured, op.Scopes = security(route.Security)
+
+	for _, p := range route.Parameters {
+		if p.Ref != "" {
+			return nil, fmt.Errorf("paramètre %s: les références de paramètres ne sont pas supportées", p.Ref)
+		}
+		goType, err := a.goType(types, p.Schema, "")
+		if err != nil {
+			return nil, fmt.Errorf("paramètre %s: %w", p.Name, err)
+		}
+		if !isScalar(goType) {
+			return nil, fmt.Errorf("paramètre %s: type %s non supporté (string, entier, nombre ou booléen)", p.Name, goType)
+		}
+		param := Param{Name: p.Name, In: p.In, GoName: goName(p.Name), Type: goType, Required: p.Required, Doc: oneLine(p.Description)}
+		if p.Schema != nil {
+			param.Rules = strings.Join(bindingRules(p.Schema), ",")
+		}
+		switch p.In {
+		case "path":
+			param.Required = true
+			param.Var = varName(p.Name)
+			op.PathParams = append(op.PathParams, param)
+		case "query", "header":
+			op.Params = append(op.Params, param)
+		default:
+			return nil, fmt.Errorf("paramètre %s: emplacement %q non supporté", p.Name, p.In)
+		}
+	}
+	if len(op.Params) > 0 {
+		op.ParamsType = name + "Params"
+		decl := &TypeDecl{Name: op.ParamsType, Doc: fmt.Sprintf("paramètres query et header de %s", name)}
+		for _, p := range op.Params {
+			f := Field{Name: p.GoName, Type: p.Type, JSON: p.Name, Doc: p.Doc}
+			if !p.Required {
+				f.Type = "*" + p.Type
+				f.JSON += ",omitempty"
+			}
+			decl.Fields = append(decl.Fields, f)
+		}
+		if _, ok := types[decl.Name]; ok {
+			return nil, fmt.Errorf("type %s déjà déclaré", decl.Name)
+		}
+		types[decl.Name] = decl
+	}
+
+	if spec.RequestBody != nil {
+		if spec.RequestBody.Ref != "" {
+			return nil, fmt.Errorf("requestBody %s: les références de requestBody ne sont pas supportées", spec.RequestBody.Ref)
+		}
+		schema := JSONSchema(spec.RequestBody.Content)
+		if schema == nil {
+			return nil, fmt.Errorf("requestBody sans contenu application/json")
+		}
+		bodyType, err := a.goType(types, schema, name+"Body")
+		if err != nil {
+			return nil, fmt.Errorf("requestBody: %w", err)
+		}
+		op.BodyType = bodyType
+		op.BodyDoc = oneLine(spec.RequestBody.Description)
+		op.BodyRequired = spec.RequestBody.Required
+		op.BodyIsStruct = a.structs[bodyType]
+	}
+
+	codes := make([]string, 0, len(spec.Responses))
+	for code := range spec.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		response := spec.Responses[code]
+		if !strings.HasPrefix(code, "2") || op.Success != "" {
+			if _, err := strconv.Atoi(code); err == nil {
+				op.Failures = append(op.Failures, code)
+			}
+			continue
+		}
+		op.Success = code
+		if schema := JSONSchema(response.Content); schema != nil {
+			responseType, err := a.goType(types, schema, name+"Response")
+			if err != nil {
+				return nil, fmt.Errorf("réponse %s: %w", code, err)
+			}
+			if strings.HasPrefix(responseType, "[]") {
+				op.SuccessType = "{array} " + strings.TrimPrefix(responseType, "[]")
+			} else {
+				op.SuccessType = "{object} " + responseType
+			}
+		}
+	}
+	if op.Success == "" {
+		op.Success = "200"
+	}
+	return op, nil
+}
+
+// security authentification et permissions exigées par la sécurité d'une opération:
+// une exigence vide ({}) rend l'authentification optionnelle donc la route publique,
+// les scopes de tous les schémas de la première exigence sont les permissions requises
+func security(requirements []SecurityRequirement) (bool, []string) {
+	if len(requirements) == 0 || slices.ContainsFunc(requirements, func(r SecurityRequirement) bool { return len(r) == 0 }) {
+		return false, nil
+	}
+	var scopes []string
+	for _, schemeScopes := range requirements[0] {
+		for _, scope := range schemeScopes {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	sort.Strings(scopes)
+	return true, scopes
+}
+
+// RouteOptions options passées à r.Handle, ex: `, httpgateway.Permissions("pets:write")`
+func (o *Endpoint) RouteOptions() string {
+	switch {
+	case len(o.Scopes) > 0:
+		scopes := make([]string, len(o.Scopes))
+		for i, scope := range o.Scopes {
+			scopes[i] = strconv.Quote(scope)
+		}
+		return ", httpgateway.Permissions(" + strings.Join(scopes, ", ") + ")"
+	case o.Secured:
+		return ", httpgateway.Authenticated()"
+	}
+	return ""
+}
+
+// Secured au moins une opération réservée aux utilisateurs authentifiés
+func (a *Api) Secured() bool {
+	return slices.ContainsFunc(a.Endpoints, func(o *Endpoint) bool { return o.Secured })
+}
+
+// Signature arguments du handler, ex: "ctx httpgateway.Context, petID string, body NewPet"
+func (o *Endpoint) Signature() string {
+	args := []string{"ctx httpgateway.Context"}
+	for _, p := range o.PathParams {
+		args = append(args, p.Var+" "+p.Type)
+	}
+	if o.ParamsType != "" {
+		args = append(args, "params "+o.ParamsType)
+	}
+	if o.BodyType != "" {
+		args = append(args, "body "+o.BodyType)
+	}
+	return strings.Join(args, ", ")
+}
+
+func isObject(schema *Schema) bool {
+	return schema.Type == "object" || (schema.Type == "" && len(schema.Properties) > 0)
+}
+
+func isScalar(goType string) bool {
+	switch goType {
+	case "string", "int", "int32", "int64", "float32", "float64", "bool":
+		return true
+	}
+	return false
+}
+
+func isNumberOrBool(goType string) bool {
+	return isScalar(goType) && goType != "string"
+}
+
+// bindingRules règles binding déduites des contraintes du schéma
+func bindingRules(schema *Schema) []string {
+	var rules []string
+	if schema.MinLength != nil {
+		rules = append(rules, fmt.Sprintf("min=%d", *schema.MinLength))
+	}
+	if schema.MaxLength != nil {
+		rules = append(rules, fmt.Sprintf("max=%d", *schema.MaxLength))
+	}
+	if schema.MinItems != nil {
+		rules = append(rules, fmt.Sprintf("min=%d", *schema.MinItems))
+	}
+	if schema.MaxItems != nil {
+		rules = append(rules, fmt.Sprintf("max=%d", *schema.MaxItems))
+	}
+	if schema.Minimum != nil {
+		rules = append(rules, "gte="+strconv.FormatFloat(*schema.Minimum, 'f', -1, 64))
+	}
+	if schema.Maximum != nil {
+		rules = append(rules, "lte="+strconv.FormatFloat(*schema.Maximum, 'f', -1, 64))
+	}
+	if len(schema.Enum) > 0 && schema.Type == "string" {
+		var values []string
+		for _, v := range schema.Enum {
+			s := fmt.Sprint(v)
+			if s == "" || strings.ContainsAny(s, " ,|\"`") {
+				// valeur non exprimable dans un tag oneof
+				return rules
+			}
+			values = append(values, s)
+		}
+		rules = append(rules, "oneof="+strings.Join(values, " "))
+	}
+	return rules
+}
+
+// routerPath convertit un chemin OpenAPI en chemin du router, ex: /pets/{petId} => /pets/:petId
+func routerPath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '{':
+			b.WriteByte(':')
+		case '}':
+		default:
+			b.WriteByte(path[i])
+		}
+	}
+	return b.String()
+}
+
+func goName(name string) string {
+	return tools.PascalCase(tools.SplitWords(name))
+}
+
+func varName(name string) string {
+	v := tools.CamelCase(tools.SplitWords(name))
+	// les mots-clés et les noms utilisés par le wrapper généré sont suffixés
+	if token.IsKeyword(v) || slices.Contains([]string{"ctx", "params", "body", "w", "v", "ok", "err"}, v) {
+		return v + "Value"
+	}
+	return v
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
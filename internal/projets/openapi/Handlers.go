@@ -0,0 +1,96 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HandlerIssue handler écrit à la main qui ne correspond plus à la spec
+type HandlerIssue struct {
+	File    string
+	Method  string
+	Message string
+}
+
+// CheckHandlers compare les méthodes de Controller déjà écrites dans dir avec les opérations de la spec
+// signale les signatures modifiées et les handlers dont l'opération a disparu de la spec
+func CheckHandlers(dir string, api *Api) ([]HandlerIssue, error) {
+	expected := map[string]*Endpoint{}
+	for _, op := range api.Endpoints {
+		expected[op.Name] = op
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", dir, err)
+	}
+
+	var issues []HandlerIssue
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, ".gen.go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parsing de %s: %w", name, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !isControllerMethod(fn) || !fn.Name.IsExported() || fn.Name.Name == "RegisterRoutes" {
+				continue
+			}
+			op, ok := expected[fn.Name.Name]
+			if !ok {
+				issues = append(issues, HandlerIssue{File: name, Method: fn.Name.Name, Message: "opération absente de la spec, handler à supprimer"})
+				continue
+			}
+			if got, want := paramTypes(fn), op.ParamTypes(); got != want {
+				issues = append(issues, HandlerIssue{File: name, Method: fn.Name.Name, Message: fmt.Sprintf("signature à mettre à jour: (%s)", op.Signature())})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// ParamTypes types des arguments du handler, ex: "httpgateway.Context, string, NewPet"
+func (o *Endpoint) ParamTypes() string {
+	var list []string
+	for _, arg := range strings.Split(o.Signature(), ", ") {
+		list = append(list, arg[strings.Index(arg, " ")+1:])
+	}
+	return strings.Join(list, ", ")
+}
+
+func isControllerMethod(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return false
+	}
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	id, ok := star.X.(*ast.Ident)
+	return ok && id.Name == "Controller"
+}
+
+func paramTypes(fn *ast.FuncDecl) string {
+	var list []string
+	for _, field := range fn.Type.Params.List {
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for range n {
+			list = append(list, types.ExprString(field.Type))
+		}
+	}
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Document sous-ensemble d'une spec OpenAPI 3 utilisé par le générateur (YAML ou JSON)
+type Document struct {
+	OpenAPI    string              `yaml:"openapi"`
+	Info       Info                `yaml:"info"`
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components Components          `yaml:"components"`
+	// Security sécurité par défaut des opérations
+	Security []SecurityRequirement `yaml:"security"`
+}
+
+// SecurityRequirement schémas de sécurité exigés et leurs scopes, ex: {bearerAuth: [pets:write]}
+type SecurityRequirement map[string][]string
+
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `yaml:"schemas"`
+}
+
+type PathItem struct {
+	Parameters []Parameter `yaml:"parameters"`
+	Get        *Operation  `yaml:"get"`
+	Post       *Operation  `yaml:"post"`
+	Put        *Operation  `yaml:"put"`
+	Patch      *Operation  `yaml:"patch"`
+	Delete     *Operation  `yaml:"delete"`
+}
+
+type Operation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	Tags        []string            `yaml:"tags"`
+	Parameters  []Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody        `yaml:"requestBody"`
+	Responses   map[string]Response `yaml:"responses"`
+	// Security remplace la sécurité du document si présent, [] rend l'opération publique
+	Security *[]SecurityRequirement `yaml:"security"`
+}
+
+type Parameter struct {
+	Ref         string  `yaml:"$ref"`
+	Name        string  `yaml:"name"`
+	In          string  `yaml:"in"`
+	Description string  `yaml:"description"`
+	Required    bool    `yaml:"required"`
+	Schema      *Schema `yaml:"schema"`
+}
+
+type RequestBody struct {
+	Ref         string               `yaml:"$ref"`
+	Description string               `yaml:"description"`
+	Required    bool                 `yaml:"required"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+type Response struct {
+	Ref         string               `yaml:"$ref"`
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `yaml:"$ref"`
+	Type                 string             `yaml:"type"`
+	Format               string             `yaml:"format"`
+	Description          string             `yaml:"description"`
+	Properties           map[string]*Schema `yaml:"properties"`
+	Required             []string           `yaml:"required"`
+	Items                *Schema            `yaml:"items"`
+	AdditionalProperties any                `yaml:"additionalProperties"`
+	Enum                 []any              `yaml:"enum"`
+	Nullable             bool               `yaml:"nullable"`
+	MinLength            *int               `yaml:"minLength"`
+	MaxLength            *int               `yaml:"maxLength"`
+	Minimum              *float64           `yaml:"minimum"`
+	Maximum              *float64           `yaml:"maximum"`
+	MinItems             *int               `yaml:"minItems"`
+	MaxItems             *int               `yaml:"maxItems"`
+	AllOf                []*Schema          `yaml:"allOf"`
+	OneOf                []*Schema          `yaml:"oneOf"`
+	AnyOf                []*Schema          `yaml:"anyOf"`
+}
+
+// Extra schéma des valeurs d'un objet libre (additionalProperties), nil si non typé (true ou absent)
+func (s *Schema) Extra() (*Schema, error) {
+	values, ok := s.AdditionalProperties.(map[string]any)
+	if !ok || len(values) == 0 {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("additionalProperties: %w", err)
+	}
+	var extra Schema
+	if err := yaml.Unmarshal(data, &extra); err != nil {
+		return nil, fmt.Errorf("additionalProperties: %w", err)
+	}
+	return &extra, nil
+}
+
+// Route opération d'un chemin, dans l'ordre des chemins puis des méthodes
+type Route struct {
+	Method    string
+	Path      string
+	Operation *Operation
+	// Parameters paramètres du chemin et de l'opération (ceux de l'opération sont prioritaires)
+	Parameters []Parameter
+	// Security sécurité de l'opération, ou celle du document
+	Security []SecurityRequirement
+}
+
+// Load lit une spec OpenAPI 3 (YAML ou JSON)
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", path, err)
+	}
+
+	var doc Document
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse de %s: %w", path, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s: seules les specs OpenAPI 3.x sont supportées (openapi: %q)", path, doc.OpenAPI)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("%s: aucun chemin dans paths", path)
+	}
+	return &doc, nil
+}
+
+// Routes opérations de la spec triées par chemin puis par méthode
+func (d *Document) Routes() []Route {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var routes []Route
+	for _, path := range paths {
+		item := d.Paths[path]
+		for _, m := range []struct {
+			method string
+			op     *Operation
+		}{
+			{"GET", item.Get},
+			{"POST", item.Post},
+			{"PUT", item.Put},
+			{"PATCH", item.Patch},
+			{"DELETE", item.Delete},
+		} {
+			if m.op == nil {
+				continue
+			}
+			security := d.Security
+			if m.op.Security != nil {
+				security = *m.op.Security
+			}
+			routes = append(routes, Route{
+				Method:     m.method,
+				Path:       path,
+				Operation:  m.op,
+				Parameters: mergeParameters(item.Parameters, m.op.Parameters),
+				Security:   security,
+			})
+		}
+	}
+	return routes
+}
+
+// mergeParameters fusionne les paramètres du chemin et de l'opération (même nom et même emplacement: l'opération l'emporte)
+func mergeParameters(pathParams, opParams []Parameter) []Parameter {
+	var merged []Parameter
+	for _, p := range pathParams {
+		overridden := false
+		for _, o := range opParams {
+			if o.Name == p.Name && o.In == p.In {
+				overridden = true
+			}
+		}
+		if !overridden {
+			merged = append(merged, p)
+		}
+	}
+	return append(merged, opParams...)
+}
+
+// SchemaName nom du schéma référencé par "#/components/schemas/<nom>"
+func SchemaName(ref string) (string, error) {
+	const prefix = "#/components/schemas/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("référence %q non supportée (seul %s<nom> est supporté)", ref, prefix)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
+
+// JSONSchema schéma du contenu application/json, nil si absent
+func JSONSchema(content map[string]MediaType) *Schema {
+	for mediaType, media := range content {
+		if strings.HasPrefix(mediaType, "application/json") {
+			return media.Schema
+		}
+	}
+	return nil
+}
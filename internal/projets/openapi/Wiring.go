@@ -0,0 +1,18 @@
+package openapi
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/wiring"
+)
+
+// Registration câblage du controller de la spec dans getControllers() du main.go
+func Registration(api *Api) wiring.Registration {
+	return wiring.Registration{
+		Imports: []string{
+			fmt.Sprintf("%s/internal/application/controller/%s", api.ModuleName, api.Package),
+		},
+		Controllers: []string{
+			api.Package + ".New()",
+		},
+	}
+}
@@ -0,0 +1,218 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+)
+
+func ControllerContent(moduleName string, r *Resource) string {
+	return fmt.Sprintf(`package %[2]scontroller
+
+import (
+	"%[1]s/internal/application/gateway/httpgateway"
+	"%[1]s/internal/application/usecase/%[2]susecase"
+)
+
+type %[3]sController struct {
+	useCase   *%[2]susecase.%[3]sUseCase
+	prefixUrl string
+}
+
+// New crée une nouvelle instance du controller %[3]s
+func New(useCase *%[2]susecase.%[3]sUseCase) *%[3]sController {
+	return &%[3]sController{
+		useCase:   useCase,
+		prefixUrl: "%[4]s",
+	}
+}
+
+// RegisterRoutes enregistre les routes du controller
+func (c *%[3]sController) RegisterRoutes(r httpgateway.Router) {
+	r.Handle("POST", c.prefixUrl, c.Create%[3]s%[6]s)
+	r.Handle("GET", c.prefixUrl, c.GetAll%[3]s%[5]s)
+	r.Handle("GET", c.prefixUrl+"/:id", c.Get%[3]s%[5]s)
+	r.Handle("PUT", c.prefixUrl+"/:id", c.Update%[3]s%[6]s)
+	r.Handle("DELETE", c.prefixUrl+"/:id", c.Delete%[3]s%[6]s)
+}
+`, moduleName, r.Package, r.Name, r.Route, r.ReadAccess.RouteOptions(), r.WriteAccess.RouteOptions())
+}
+
+func CreateContent(moduleName string, r *Resource) string {
+	return fmt.Sprintf(`package %[2]scontroller
+
+import "%[1]s/internal/application/gateway/httpgateway"
+
+// Create%[3]s crée un nouveau %[3]s
+// @Summary Créer un %[3]s
+// @Description Crée un nouveau %[3]s
+// @Tags %[3]s
+// @Accept json
+// @Produce json%[7]s
+// @Param body body Create%[3]sRequest true "%[3]s à créer"
+// @Success 201 {object} %[3]sResponse
+// @Failure 400 {object} map[string]string%[8]s
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router %[4]s [post]
+func (c *%[3]sController) Create%[3]s(ctx httpgateway.Context) {
+	var req Create%[3]sRequest
+	if !ctx.BindJSON(&req) {
+		return
+	}
+
+	%[5]sEntity, err := c.useCase.Create(ctx.Request().Context(), req.ToEntity())
+	if err != nil {
+		ctx.Error("Erreur lors de la création", err)
+		return
+	}
+
+	ctx.Created("Le %[6]s a été créé", New%[3]sResponse(%[5]sEntity))
+}
+`, moduleName, r.Package, r.Name, r.Route, r.Var, r.Lower(), r.WriteAccess.SwaggerSecurity(), r.WriteAccess.SwaggerFailures())
+}
+
+func GetAllContent(moduleName string, r *Resource) string {
+	var filters []string
+	for _, f := range r.Fields {
+		filters = append(filters, fmt.Sprintf("\n// @Param %s query %s false \"Filtre sur %s\"", f.Key, f.SwaggerType(), f.Key))
+	}
+
+	return gofmt(fmt.Sprintf(`package %[2]scontroller
+
+import (
+	"%[1]s/internal/application/gateway/httpgateway"
+	"%[1]s/internal/domain/%[2]s"
+	"%[1]s/internal/domain/pagination"
+	"strconv"
+)
+
+// GetAll%[3]s récupère une page de %[3]s
+// @Summary Récupérer les %[3]s
+// @Description Récupère une page de %[3]s, le nombre total est aussi dans l'en-tête X-Total-Count
+// @Tags %[3]s
+// @Accept json
+// @Produce json%[7]s
+// @Param page query int false "Page, à partir de 1"
+// @Param limit query int false "Éléments par page (20 par défaut, 100 maximum)"
+// @Param cursor query string false "Curseur de la page suivante (nextCursor), remplace page"
+// @Param sort query string false "Tri, ex: %[9]s ou -%[9]s"%[10]s
+// @Success 200 {object} pagination.Result[%[3]sResponse]
+// @Header 200 {integer} X-Total-Count "Nombre total de %[3]s"%[8]s
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router %[4]s [get]
+func (c *%[3]sController) GetAll%[3]s(ctx httpgateway.Context) {
+	query, err := pagination.Parse(ctx.Request().URL.Query(), %[2]s.Fields...)
+	if err != nil {
+		ctx.Error("Paramètres de liste invalides", err)
+		return
+	}
+
+	page, err := c.useCase.GetAll(ctx.Request().Context(), query)
+	if err != nil {
+		ctx.Error("Erreur lors de la récupération", err)
+		return
+	}
+
+	ctx.SetHeader("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	ctx.Success("Récupération des %[6]s", pagination.Map(page, New%[3]sResponse))
+}
+`, moduleName, r.Package, r.Name, r.Route, r.Var, r.Lower(), r.ReadAccess.SwaggerSecurity(), r.ReadAccess.SwaggerFailures(), r.Fields[0].Key, strings.Join(filters, "")))
+}
+
+func GetContent(moduleName string, r *Resource) string {
+	return fmt.Sprintf(`package %[2]scontroller
+
+import "%[1]s/internal/application/gateway/httpgateway"
+
+// Get%[3]s récupère un %[3]s par son ID
+// @Summary Récupérer un %[3]s
+// @Description Récupère un %[3]s par son ID
+// @Tags %[3]s
+// @Accept json
+// @Produce json%[7]s
+// @Param id path string true "ID du %[3]s"
+// @Success 200 {object} %[3]sResponse%[8]s
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router %[4]s/{id} [get]
+func (c *%[3]sController) Get%[3]s(ctx httpgateway.Context) {
+	%[5]sEntity, err := c.useCase.GetByID(ctx.Request().Context(), ctx.Param("id"))
+	if err != nil {
+		ctx.Error("Erreur lors de la récupération", err)
+		return
+	}
+
+	ctx.Success("Récupération du %[6]s", New%[3]sResponse(%[5]sEntity))
+}
+`, moduleName, r.Package, r.Name, r.Route, r.Var, r.Lower(), r.ReadAccess.SwaggerSecurity(), r.ReadAccess.SwaggerFailures())
+}
+
+func UpdateContent(moduleName string, r *Resource) string {
+	return fmt.Sprintf(`package %[2]scontroller
+
+import "%[1]s/internal/application/gateway/httpgateway"
+
+// Update%[3]s met à jour un %[3]s
+// @Summary Mettre à jour un %[3]s
+// @Description Met à jour les champs fournis d'un %[3]s existant
+// @Tags %[3]s
+// @Accept json
+// @Produce json%[7]s
+// @Param id path string true "ID du %[3]s"
+// @Param body body Update%[3]sRequest true "Champs à modifier"
+// @Success 200 {object} %[3]sResponse
+// @Failure 400 {object} map[string]string%[8]s
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router %[4]s/{id} [put]
+func (c *%[3]sController) Update%[3]s(ctx httpgateway.Context) {
+	var req Update%[3]sRequest
+	if !ctx.BindJSON(&req) {
+		return
+	}
+
+	%[5]sEntity, err := c.useCase.GetByID(ctx.Request().Context(), ctx.Param("id"))
+	if err == nil {
+		req.Apply(%[5]sEntity)
+		%[5]sEntity, err = c.useCase.Update(ctx.Request().Context(), %[5]sEntity)
+	}
+	if err != nil {
+		ctx.Error("Erreur lors de la mise à jour", err)
+		return
+	}
+
+	ctx.Success("Le %[6]s a été mis à jour", New%[3]sResponse(%[5]sEntity))
+}
+`, moduleName, r.Package, r.Name, r.Route, r.Var, r.Lower(), r.WriteAccess.SwaggerSecurity(), r.WriteAccess.SwaggerFailures())
+}
+
+func DeleteContent(moduleName string, r *Resource) string {
+	return fmt.Sprintf(`package %[2]scontroller
+
+import "%[1]s/internal/application/gateway/httpgateway"
+
+// Delete%[3]s supprime un %[3]s
+// @Summary Supprimer un %[3]s
+// @Description Supprime un %[3]s par son ID
+// @Tags %[3]s
+// @Accept json
+// @Produce json%[6]s
+// @Param id path string true "ID du %[3]s"
+// @Success 204%[7]s
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router %[4]s/{id} [delete]
+func (c *%[3]sController) Delete%[3]s(ctx httpgateway.Context) {
+	if err := c.useCase.Delete(ctx.Request().Context(), ctx.Param("id")); err != nil {
+		ctx.Error("Erreur lors de la suppression", err)
+		return
+	}
+
+	ctx.NoContent("Le %[5]s a été supprimé")
+}
+`, moduleName, r.Package, r.Name, r.Route, r.Lower(), r.WriteAccess.SwaggerSecurity(), r.WriteAccess.SwaggerFailures())
+}
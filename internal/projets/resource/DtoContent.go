@@ -0,0 +1,103 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+)
+
+func DtoContent(moduleName string, r *Resource) string {
+	var createFields, updateFields, responseFields, responseAssigns, args, optionals, applies []string
+	for _, f := range r.Fields {
+		createFields = append(createFields, fmt.Sprintf("\t%s %s `json:\"%s\"%s`", f.Name, f.createType(), f.Key, f.bindingTag(true)))
+		updateFields = append(updateFields, fmt.Sprintf("\t%s *%s `json:\"%s\"%s`", f.Name, f.Type, f.Key, f.bindingTag(false)))
+		responseFields = append(responseFields, fmt.Sprintf("\t%s %s `json:\"%s\"`", f.Name, f.Type, f.Key))
+		responseAssigns = append(responseAssigns, fmt.Sprintf("\t\t%s: %sEntity.%s,", f.Name, r.Var, f.Name))
+		applies = append(applies, fmt.Sprintf("\tif req.%[1]s != nil {\n\t\t%[2]sEntity.%[1]s = *req.%[1]s\n\t}", f.Name, r.Var))
+
+		switch {
+		case f.Required && strings.HasPrefix(f.createType(), "*"):
+			args = append(args, "*req."+f.Name)
+		case f.Required:
+			args = append(args, "req."+f.Name)
+		default:
+			optionals = append(optionals, fmt.Sprintf("\tif req.%[1]s != nil {\n\t\t%[2]sEntity.%[1]s = *req.%[1]s\n\t}", f.Name, r.Var))
+		}
+	}
+
+	imports := fmt.Sprintf("\t\"%s/internal/domain/%s\"\n\t\"time\"\n", moduleName, r.Package)
+
+	toEntity := fmt.Sprintf("\treturn %s.New%s(%s)", r.Package, r.Name, strings.Join(args, ", "))
+	if len(optionals) > 0 {
+		toEntity = fmt.Sprintf("\t%[1]sEntity := %[2]s.New%[3]s(%[4]s)\n%[5]s\n\treturn %[1]sEntity", r.Var, r.Package, r.Name, strings.Join(args, ", "), strings.Join(optionals, "\n"))
+	}
+
+	return gofmt(fmt.Sprintf(`package %[2]scontroller
+
+import (
+%[4]s)
+
+// Create%[3]sRequest corps de la requête de création, validé par ctx.BindJSON selon ses tags binding
+type Create%[3]sRequest struct {
+%[5]s
+}
+
+// ToEntity construit l'entité, les champs optionnels absents gardent leur valeur par défaut
+func (req *Create%[3]sRequest) ToEntity() *%[2]s.%[3]s {
+%[10]s
+}
+
+// Update%[3]sRequest corps de la requête de mise à jour, seuls les champs fournis sont modifiés
+type Update%[3]sRequest struct {
+%[6]s
+}
+
+// Apply applique les champs fournis à l'entité
+func (req *Update%[3]sRequest) Apply(%[1]sEntity *%[2]s.%[3]s) {
+%[9]s
+}
+
+// %[3]sResponse %[3]s renvoyé par l'api
+type %[3]sResponse struct {
+	ID string `+"`json:\"id\"`"+`
+%[7]s
+	CreatedAt time.Time `+"`json:\"createdAt\"`"+`
+	UpdatedAt time.Time `+"`json:\"updatedAt\"`"+`
+}
+
+// New%[3]sResponse construit la réponse à partir de l'entité
+func New%[3]sResponse(%[1]sEntity *%[2]s.%[3]s) %[3]sResponse {
+	return %[3]sResponse{
+		ID: %[1]sEntity.ID,
+%[8]s
+		CreatedAt: %[1]sEntity.CreatedAt,
+		UpdatedAt: %[1]sEntity.UpdatedAt,
+	}
+}
+`, r.Var, r.Package, r.Name, imports, strings.Join(createFields, "\n"), strings.Join(updateFields, "\n"), strings.Join(responseFields, "\n"), strings.Join(responseAssigns, "\n"), strings.Join(applies, "\n"), toEntity))
+}
+
+// createType type du champ dans la requête de création
+// les champs optionnels et les obligatoires dont la valeur zéro est valide (nombres, booléens) sont des pointeurs
+func (f Field) createType() string {
+	if f.Required && (f.Type == "string" || f.Type == "time.Time" || strings.HasPrefix(f.Type, "[]")) {
+		return f.Type
+	}
+	return "*" + f.Type
+}
+
+// bindingTag tag binding du champ, vide sans règle
+func (f Field) bindingTag(create bool) string {
+	var rules []string
+	if create && f.Required {
+		rules = append(rules, "required")
+	} else if f.Validate != "" {
+		rules = append(rules, "omitempty")
+	}
+	if f.Validate != "" {
+		rules = append(rules, f.Validate)
+	}
+	if len(rules) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" binding:\"%s\"", strings.Join(rules, ","))
+}
@@ -0,0 +1,111 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+)
+
+func EntityContent(r *Resource) string {
+	var fields, params, assigns []string
+	hasDefault := false
+	for _, f := range r.Fields {
+		fields = append(fields, fmt.Sprintf("\t%s %s `bson:\"%s\" json:\"%s\"`", f.Name, f.Type, f.Key, f.Key))
+		switch {
+		case f.Required:
+			params = append(params, fmt.Sprintf("%s %s", f.Param(), f.Type))
+			assigns = append(assigns, fmt.Sprintf("\t\t%s: %s,", f.Name, f.Param()))
+		case f.Default != "":
+			hasDefault = true
+			assigns = append(assigns, fmt.Sprintf("\t\t%s: %s,", f.Name, f.Default))
+		}
+	}
+
+	doc := ""
+	if hasDefault {
+		doc = "\n// les champs optionnels prennent leur valeur par défaut"
+	}
+
+	return gofmt(fmt.Sprintf(`package %[1]s
+
+import "time"
+
+// %[2]s représente l'entité métier %[2]s
+// CreatedAt et UpdatedAt sont renseignés par le repository
+type %[2]s struct {
+	ID string `+"`bson:\"_id,omitempty\" json:\"id\"`"+`
+%[3]s
+	CreatedAt time.Time `+"`bson:\"createdAt\" json:\"createdAt\"`"+`
+	UpdatedAt time.Time `+"`bson:\"updatedAt\" json:\"updatedAt\"`"+`
+}
+
+// New%[2]s crée une nouvelle instance de %[2]s%[6]s
+func New%[2]s(%[4]s) *%[2]s {
+	return &%[2]s{
+%[5]s
+	}
+}
+`, r.Package, r.Name, strings.Join(fields, "\n"), strings.Join(params, ", "), strings.Join(assigns, "\n"), doc))
+}
+
+func RepositoryInterfaceContent(moduleName string, r *Resource) string {
+	var fields []string
+	for _, f := range r.Fields {
+		fields = append(fields, "\t"+f.PaginationField()+",")
+	}
+	fields = append(fields, "\tpagination.Time(\"createdAt\"),", "\tpagination.Time(\"updatedAt\"),")
+
+	var finders []string
+	for _, f := range r.UniqueFields() {
+		finders = append(finders, fmt.Sprintf(`
+	// FindBy%[1]s récupère un %[2]s par son %[3]s
+	FindBy%[1]s(ctx context.Context, %[4]s %[5]s) (*%[2]s, error)
+`, f.Name, r.Name, f.Key, f.Param(), f.Type))
+	}
+
+	imports := "\t\"context\"\n\t\"" + moduleName + "/internal/domain/domainerror\"\n\t\"" + moduleName + "/internal/domain/pagination\"\n"
+	if usesTime(r.UniqueFields()) {
+		imports += "\t\"time\"\n"
+	}
+
+	deleteDoc := ""
+	if r.SoftDelete {
+		deleteDoc = "\n\t// suppression logique: deletedAt est renseigné et le " + r.Lower() + " n'est plus lu"
+	}
+
+	return gofmt(fmt.Sprintf(`package %[1]s
+
+import (
+%[6]s)
+
+var (
+	// ErrNotFound retourné par le repository quand le %[3]s n'existe pas
+	ErrNotFound = domainerror.NotFound("%[3]s non trouvé")
+
+	// ErrAlreadyExists retourné quand la valeur d'un champ unique est déjà utilisée par un autre %[3]s
+	ErrAlreadyExists = domainerror.Conflict("%[3]s déjà existant")
+)
+
+// Fields champs triables et filtrables des listes de %[2]s
+var Fields = []pagination.Field{
+%[7]s
+}
+
+// %[2]sRepository définit les opérations de persistance pour l'entité %[2]s
+type %[2]sRepository interface {
+	// FindByID récupère un %[2]s par son ID
+	FindByID(ctx context.Context, id string) (*%[2]s, error)
+%[5]s
+	// FindAll récupère une page de %[2]s selon la pagination, le tri et les filtres de query
+	FindAll(ctx context.Context, query pagination.Query) (pagination.Result[*%[2]s], error)
+
+	// Create crée un nouveau %[2]s
+	Create(ctx context.Context, %[4]s *%[2]s) error
+
+	// Update met à jour un %[2]s existant
+	Update(ctx context.Context, %[4]s *%[2]s) error
+
+	// Delete supprime un %[2]s par son ID%[8]s
+	Delete(ctx context.Context, id string) error
+}
+`, r.Package, r.Name, r.Lower(), r.Var, strings.Join(finders, ""), imports, strings.Join(fields, "\n"), deleteDoc))
+}
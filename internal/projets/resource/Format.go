@@ -0,0 +1,13 @@
+package resource
+
+import "go/format"
+
+// gofmt formate le code généré (alignement des champs et des tags)
+// le code est retourné tel quel s'il ne peut pas être formaté
+func gofmt(src string) string {
+	out, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return string(out)
+}
@@ -0,0 +1,69 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+)
+
+func MongoRepositoryContent(moduleName string, r *Resource) string {
+	var sets []string
+	for _, f := range r.Fields {
+		sets = append(sets, fmt.Sprintf("\t\t%q: %sEntity.%s,", f.Key, r.Var, f.Name))
+	}
+
+	var finders []string
+	for _, f := range r.UniqueFields() {
+		finders = append(finders, fmt.Sprintf(`
+// FindBy%[3]s récupère un %[2]s par son %[4]s
+func (r *mongo%[2]sRepository) FindBy%[3]s(ctx context.Context, %[5]s %[6]s) (*%[1]s.%[2]s, error) {
+	return r.FindOne(ctx, bson.M{%[4]q: %[5]s})
+}
+`, r.Package, r.Name, f.Name, f.Key, f.Param(), f.Type))
+	}
+
+	imports := ""
+	if usesTime(r.UniqueFields()) {
+		imports = "\t\"time\"\n"
+	}
+
+	return gofmt(fmt.Sprintf(`package %[2]srepository
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"%[1]s/internal/domain/%[2]s"
+	"%[1]s/internal/infrastructure/adapter/mongoadapter"
+%[7]s)
+
+// mongo%[3]sRepository FindByID, FindAll, Create et Delete viennent de mongoadapter.Repository
+type mongo%[3]sRepository struct {
+	*mongoadapter.Repository[%[2]s.%[3]s]
+}
+
+// NewMongo%[3]sRepository crée une nouvelle instance du repository MongoDB pour %[3]s
+func NewMongo%[3]sRepository(database *mongo.Database) %[2]s.%[3]sRepository {
+	return &mongo%[3]sRepository{
+		Repository: mongoadapter.NewRepository(database.Collection(%[2]s.Schema.Name), mongoadapter.Config[%[2]s.%[3]s]{
+			ID:            func(%[4]sEntity *%[2]s.%[3]s) string { return %[4]sEntity.ID },
+			NotFound:      %[2]s.ErrNotFound,
+			AlreadyExists: %[2]s.ErrAlreadyExists,
+			SoftDelete:    %[8]t,
+		}),
+	}
+}
+%[6]s
+// Update met à jour un %[3]s existant
+func (r *mongo%[3]sRepository) Update(ctx context.Context, %[4]sEntity *%[2]s.%[3]s) error {
+	updated, err := r.UpdateByID(ctx, %[4]sEntity.ID, bson.M{
+%[5]s
+	})
+	if err != nil {
+		return err
+	}
+
+	*%[4]sEntity = *updated
+	return nil
+}
+`, moduleName, r.Package, r.Name, r.Var, strings.Join(sets, "\n"), strings.Join(finders, ""), imports, r.SoftDelete))
+}
@@ -0,0 +1,244 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/tools"
+	"go/token"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Field champ d'une entité générée
+type Field struct {
+	Name     string // nom Go (PascalCase), ex: "CreatedBy"
+	Type     string // type Go, ex: "string"
+	Key      string // nom bson/json, ex: "createdBy"
+	Required bool   // obligatoire à la création
+	Unique   bool   // une seule entité par valeur (FindBy<Name> + contrôle à la création et à la mise à jour)
+	Validate string // règles de validation (tag binding), ex: "min=3,max=120"
+	Default  string // valeur par défaut en Go, ex: `"draft"`, appliquée si le champ optionnel est absent
+}
+
+// Resource noms dérivés d'une entité, utilisés par tous les fichiers générés
+// ex pour "blog-post": Name "BlogPost", Package "blogpost", Var "blogPost", Route "/blog-post", Collection "blog_posts"
+type Resource struct {
+	Name       string
+	Package    string
+	Var        string
+	Route      string
+	Collection string
+	Fields     []Field
+	SoftDelete bool    // Delete renseigne deletedAt, les documents supprimés sont ignorés par les lectures
+	Indexes    []Index // index déclarés en plus de ceux des champs uniques
+
+	ReadAccess  Access // accès aux routes GET
+	WriteAccess Access // accès aux routes POST, PUT et DELETE
+}
+
+// Index index de la collection (table en SQL), Text et TTL MongoDB uniquement
+type Index struct {
+	Keys   []IndexKey
+	Unique bool
+	Text   bool
+	TTL    time.Duration
+}
+
+// IndexKey champ d'un index
+type IndexKey struct {
+	Field string // nom bson du champ
+	Desc  bool
+}
+
+// Access accès à des routes de la ressource, publiques par défaut
+type Access struct {
+	Authenticated bool     // réservées aux utilisateurs authentifiés
+	Roles         []string // réservées aux utilisateurs ayant un des rôles
+}
+
+// Protected routes réservées aux utilisateurs authentifiés
+func (a Access) Protected() bool {
+	return a.Authenticated || len(a.Roles) > 0
+}
+
+// RouteOptions options passées à r.Handle, ex: `, httpgateway.Roles("admin")`
+func (a Access) RouteOptions() string {
+	switch {
+	case len(a.Roles) > 0:
+		roles := make([]string, len(a.Roles))
+		for i, role := range a.Roles {
+			roles[i] = strconv.Quote(role)
+		}
+		return ", httpgateway.Roles(" + strings.Join(roles, ", ") + ")"
+	case a.Authenticated:
+		return ", httpgateway.Authenticated()"
+	}
+	return ""
+}
+
+// SwaggerSecurity annotation swag de sécurité des routes protégées
+func (a Access) SwaggerSecurity() string {
+	if !a.Protected() {
+		return ""
+	}
+	return "\n// @Security BearerAuth"
+}
+
+// SwaggerFailures réponses 401 et 403 des routes protégées
+func (a Access) SwaggerFailures() string {
+	switch {
+	case len(a.Roles) > 0:
+		return "\n// @Failure 401 {object} map[string]string\n// @Failure 403 {object} map[string]string"
+	case a.Authenticated:
+		return "\n// @Failure 401 {object} map[string]string"
+	}
+	return ""
+}
+
+// Protected au moins une route de la ressource réservée aux utilisateurs authentifiés
+func (r *Resource) Protected() bool {
+	return r.ReadAccess.Protected() || r.WriteAccess.Protected()
+}
+
+// New construit une ressource à partir d'un nom (PascalCase, camelCase, kebab-case ou snake_case)
+// l'entité a par défaut un seul champ name string obligatoire
+func New(name string) (*Resource, error) {
+	r, err := newResource(name)
+	if err != nil {
+		return nil, err
+	}
+	field := NewField("name", "string")
+	field.Required = true
+	r.Fields = []Field{field}
+	return r, nil
+}
+
+func newResource(name string) (*Resource, error) {
+	words := tools.SplitWords(name)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("nom de ressource invalide %q", name)
+	}
+	if unicode.IsDigit(rune(words[0][0])) {
+		return nil, fmt.Errorf("nom de ressource invalide %q: ne doit pas commencer par un chiffre", name)
+	}
+
+	r := &Resource{
+		Name:       tools.PascalCase(words),
+		Package:    strings.Join(words, ""),
+		Var:        tools.CamelCase(words),
+		Route:      "/" + strings.Join(words, "-"),
+		Collection: strings.Join(words, "_") + "s",
+	}
+	if token.IsKeyword(r.Package) {
+		return nil, fmt.Errorf("nom de ressource invalide %q: mot-clé Go", name)
+	}
+	return r, nil
+}
+
+// NewField construit un champ à partir de son nom bson/json et de son type Go
+func NewField(key, goType string) Field {
+	words := tools.SplitWords(key)
+	return Field{Name: tools.PascalCase(words), Type: goType, Key: tools.CamelCase(words)}
+}
+
+// Lower nom de la ressource en minuscules pour les messages, ex: "blog post"
+func (r *Resource) Lower() string {
+	return strings.Join(tools.SplitWords(r.Name), " ")
+}
+
+// RequiredFields champs passés au constructeur de l'entité
+func (r *Resource) RequiredFields() []Field {
+	var fields []Field
+	for _, f := range r.Fields {
+		if f.Required {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// fieldType type Go du champ key, dates createdAt et updatedAt comprises
+func (r *Resource) fieldType(key string) (string, bool) {
+	if key == "createdAt" || key == "updatedAt" {
+		return "time.Time", true
+	}
+	for _, f := range r.Fields {
+		if f.Key == key {
+			return f.Type, true
+		}
+	}
+	return "", false
+}
+
+// alwaysSet indique si le champ key est renseigné sur tous les documents: champ obligatoire, createdAt ou updatedAt
+func (r *Resource) alwaysSet(key string) bool {
+	if key == "createdAt" || key == "updatedAt" {
+		return true
+	}
+	for _, f := range r.Fields {
+		if f.Key == key {
+			return f.Required
+		}
+	}
+	return false
+}
+
+// UniqueFields champs avec une requête FindBy<Name>
+func (r *Resource) UniqueFields() []Field {
+	var fields []Field
+	for _, f := range r.Fields {
+		if f.Unique {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// usesTime indique si un des champs utilise le package time
+func usesTime(fields []Field) bool {
+	for _, f := range fields {
+		if strings.Contains(f.Type, "time.") {
+			return true
+		}
+	}
+	return false
+}
+
+// PaginationField champ triable et filtrable des listes, ex: `pagination.Int("stock")`
+// un champ liste est filtré sur un de ses éléments
+func (f Field) PaginationField() string {
+	kind := "String"
+	switch strings.TrimPrefix(f.Type, "[]") {
+	case "bool":
+		kind = "Bool"
+	case "int", "int32", "int64":
+		kind = "Int"
+	case "float32", "float64":
+		kind = "Float"
+	case "time.Time":
+		kind = "Time"
+	}
+	return fmt.Sprintf("pagination.%s(%q)", kind, f.Key)
+}
+
+// SwaggerType type swag du champ en paramètre de query
+func (f Field) SwaggerType() string {
+	switch strings.TrimPrefix(f.Type, "[]") {
+	case "bool":
+		return "bool"
+	case "int", "int32", "int64":
+		return "int"
+	case "float32", "float64":
+		return "number"
+	}
+	return "string"
+}
+
+// Param nom du champ utilisable comme paramètre de fonction (les mots-clés Go sont suffixés)
+func (f Field) Param() string {
+	if token.IsKeyword(f.Key) {
+		return f.Key + "Value"
+	}
+	return f.Key
+}
@@ -0,0 +1,109 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaContent collection MongoDB de la ressource: index des champs uniques et de la spec, validateur $jsonSchema des champs
+func SchemaContent(moduleName string, r *Resource) string {
+	var indexes []string
+	for _, f := range r.UniqueFields() {
+		indexes = append(indexes, fmt.Sprintf("\t\t{Keys: []schema.Key{schema.Asc(%q)}, Unique: true},", f.Key))
+	}
+	for _, index := range r.Indexes {
+		indexes = append(indexes, "\t\t"+index.literal()+",")
+	}
+
+	required := []string{}
+	properties := []string{}
+	for _, f := range r.Fields {
+		if f.Required {
+			required = append(required, fmt.Sprintf("%q", f.Key))
+		}
+		properties = append(properties, fmt.Sprintf("\t\t\t%q: %s,", f.Key, f.jsonSchema()))
+	}
+	required = append(required, `"createdAt"`, `"updatedAt"`)
+	properties = append(properties, "\t\t\t\"createdAt\": map[string]any{\"bsonType\": \"date\"},", "\t\t\t\"updatedAt\": map[string]any{\"bsonType\": \"date\"},")
+
+	indexBlock := ""
+	if len(indexes) > 0 {
+		indexBlock = "\tIndexes: []schema.Index{\n" + strings.Join(indexes, "\n") + "\n\t},\n"
+	}
+	imports := fmt.Sprintf("\t%q\n", moduleName+"/internal/domain/schema")
+	for _, index := range r.Indexes {
+		if index.TTL > 0 {
+			imports += "\t\"time\"\n"
+			break
+		}
+	}
+
+	return gofmt(fmt.Sprintf(`package %[1]s
+
+import (
+%[6]s)
+
+// Schema collection %[2]s: index et validateur appliqués au démarrage de l'api par mongoadapter.EnsureSchemas
+var Schema = schema.Collection{
+	Name: %[2]q,
+%[3]s	Validator: schema.JSONSchema{
+		"bsonType": "object",
+		"required": []string{%[4]s},
+		"properties": map[string]any{
+%[5]s
+		},
+	},
+}
+`, r.Package, r.Collection, indexBlock, strings.Join(required, ", "), strings.Join(properties, "\n"), imports))
+}
+
+// literal index en Go, ex: `{Keys: []schema.Key{schema.Asc("stock"), schema.Desc("createdAt")}}`
+func (i Index) literal() string {
+	keys := make([]string, len(i.Keys))
+	for j, key := range i.Keys {
+		switch {
+		case i.Text:
+			keys[j] = fmt.Sprintf("schema.Text(%q)", key.Field)
+		case key.Desc:
+			keys[j] = fmt.Sprintf("schema.Desc(%q)", key.Field)
+		default:
+			keys[j] = fmt.Sprintf("schema.Asc(%q)", key.Field)
+		}
+	}
+
+	literal := "{Keys: []schema.Key{" + strings.Join(keys, ", ") + "}"
+	if i.Unique {
+		literal += ", Unique: true"
+	}
+	if i.TTL > 0 {
+		literal += fmt.Sprintf(", TTL: %d * time.Second", int64(i.TTL.Seconds()))
+	}
+	return literal + "}"
+}
+
+// jsonSchema propriété $jsonSchema du champ, les listes optionnelles absentes sont enregistrées à null
+func (f Field) jsonSchema() string {
+	if item, ok := strings.CutPrefix(f.Type, "[]"); ok {
+		arrayType := `"array"`
+		if !f.Required {
+			arrayType = `[]string{"array", "null"}`
+		}
+		return fmt.Sprintf(`map[string]any{"bsonType": %s, "items": map[string]any{"bsonType": %s}}`, arrayType, bsonType(item))
+	}
+	return fmt.Sprintf(`map[string]any{"bsonType": %s}`, bsonType(f.Type))
+}
+
+// bsonType type bson d'un type Go, les entiers sont enregistrés en int ou long selon leur valeur
+func bsonType(goType string) string {
+	switch goType {
+	case "bool":
+		return `"bool"`
+	case "int", "int32", "int64":
+		return `[]string{"int", "long"}`
+	case "float32", "float64":
+		return `"double"`
+	case "time.Time":
+		return `"date"`
+	}
+	return `"string"`
+}
@@ -0,0 +1,259 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/nsevendev/starter/internal/tools"
+	"gopkg.in/yaml.v3"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Spec description YAML d'une entité
+//
+//	name: Product
+//	collection: products # optionnel, table en SQL
+//	route: /products     # optionnel
+//	softDelete: true     # optionnel, Delete renseigne deletedAt au lieu de supprimer le document
+//	fields:
+//	  - name: title
+//	    type: string
+//	    required: true
+//	    unique: true
+//	    validate: min=3,max=120
+//	  - name: stock
+//	    type: int
+//	    default: 0
+//	    validate: gte=0
+//	indexes: # optionnel, index en plus de ceux des champs unique
+//	  - fields: [stock, -createdAt] # composé, "-" pour l'ordre décroissant
+//	  - fields: [title]
+//	    text: true # recherche texte (MongoDB uniquement)
+//	  - fields: [expiresAt]
+//	    ttl: 24h   # supprime les documents 24h après la date du champ (MongoDB uniquement)
+//	access: # optionnel, routes publiques sans access (api créée avec --auth)
+//	  authenticated: true # toutes les routes réservées aux utilisateurs authentifiés
+//	  write: [admin]      # rôles des routes POST, PUT et DELETE
+type Spec struct {
+	Name       string      `yaml:"name"`
+	Collection string      `yaml:"collection"`
+	Route      string      `yaml:"route"`
+	SoftDelete bool        `yaml:"softDelete"`
+	Fields     []FieldSpec `yaml:"fields"`
+	Indexes    []IndexSpec `yaml:"indexes"`
+	Access     AccessSpec  `yaml:"access"`
+}
+
+// IndexSpec description YAML d'un index de la collection ou de la table
+type IndexSpec struct {
+	Fields []string `yaml:"fields"` // champs de l'index, "-" devant un champ pour l'ordre décroissant
+	Unique bool     `yaml:"unique"`
+	Text   bool     `yaml:"text"` // index de recherche texte sur des champs string
+	TTL    string   `yaml:"ttl"`  // durée Go, ex: 24h, index d'un seul champ date
+}
+
+// AccessSpec description YAML des accès aux routes, un des rôles suffit
+type AccessSpec struct {
+	Authenticated bool     `yaml:"authenticated"`
+	Read          []string `yaml:"read"`  // rôles des routes GET
+	Write         []string `yaml:"write"` // rôles des routes POST, PUT et DELETE
+}
+
+// FieldSpec description YAML d'un champ
+type FieldSpec struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Required bool   `yaml:"required"`
+	Unique   bool   `yaml:"unique"`
+	Validate string `yaml:"validate"`
+	Default  any    `yaml:"default"`
+}
+
+// supportedTypes types Go acceptés pour un champ
+var supportedTypes = []string{"string", "bool", "int", "int32", "int64", "float32", "float64", "time.Time", "[]string", "[]int", "[]float64"}
+
+// timestampKeys champs des dates maintenues par mongoadapter.Repository
+var timestampKeys = []string{"createdAt", "updatedAt", "deletedAt"}
+
+// LoadSpec lit une spec YAML et construit la ressource
+// name remplace le nom de la spec s'il est renseigné
+func LoadSpec(path, name string) (*Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", path, err)
+	}
+
+	var spec Spec
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("parse YAML %s: %w", path, err)
+	}
+	if name != "" {
+		spec.Name = name
+	}
+
+	r, err := spec.Resource()
+	if err != nil {
+		return nil, fmt.Errorf("spec %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Resource valide la spec et construit la ressource
+func (s *Spec) Resource() (*Resource, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("name absent")
+	}
+	r, err := newResource(s.Name)
+	if err != nil {
+		return nil, err
+	}
+	if s.Collection != "" {
+		r.Collection = s.Collection
+	}
+	if s.Route != "" {
+		r.Route = "/" + strings.Trim(s.Route, "/")
+	}
+	r.SoftDelete = s.SoftDelete
+
+	if len(s.Fields) == 0 {
+		return nil, fmt.Errorf("aucun champ dans fields")
+	}
+	seen := map[string]bool{}
+	for i, fs := range s.Fields {
+		f, err := fs.field()
+		if err != nil {
+			return nil, fmt.Errorf("fields[%d] %s: %w", i, fs.Name, err)
+		}
+		if seen[f.Key] {
+			return nil, fmt.Errorf("fields[%d]: champ %s en double", i, f.Key)
+		}
+		seen[f.Key] = true
+		r.Fields = append(r.Fields, f)
+	}
+
+	for i, is := range s.Indexes {
+		index, err := is.index(r)
+		if err != nil {
+			return nil, fmt.Errorf("indexes[%d]: %w", i, err)
+		}
+		r.Indexes = append(r.Indexes, index)
+	}
+
+	for _, role := range slices.Concat(s.Access.Read, s.Access.Write) {
+		if role == "" || strings.ContainsAny(role, "`\"") {
+			return nil, fmt.Errorf("access: rôle invalide %q", role)
+		}
+	}
+	r.ReadAccess = Access{Authenticated: s.Access.Authenticated, Roles: s.Access.Read}
+	r.WriteAccess = Access{Authenticated: s.Access.Authenticated, Roles: s.Access.Write}
+	return r, nil
+}
+
+func (fs FieldSpec) field() (Field, error) {
+	if len(tools.SplitWords(fs.Name)) == 0 {
+		return Field{}, fmt.Errorf("nom de champ invalide")
+	}
+	if !slices.Contains(supportedTypes, fs.Type) {
+		return Field{}, fmt.Errorf("type %q non supporté (types acceptés: %s)", fs.Type, strings.Join(supportedTypes, ", "))
+	}
+
+	f := NewField(fs.Name, fs.Type)
+	if f.Key == "id" {
+		return Field{}, fmt.Errorf("id est réservé (champ _id généré par MongoDB)")
+	}
+	if slices.Contains(timestampKeys, f.Key) {
+		return Field{}, fmt.Errorf("%s est réservé (date gérée par le repository)", f.Key)
+	}
+	if strings.ContainsAny(fs.Validate, "`\"") {
+		return Field{}, fmt.Errorf("règle de validation invalide %q", fs.Validate)
+	}
+	if fs.Unique && strings.HasPrefix(fs.Type, "[]") {
+		return Field{}, fmt.Errorf("un champ %s ne peut pas être unique", fs.Type)
+	}
+	if fs.Required && fs.Default != nil {
+		return Field{}, fmt.Errorf("un champ obligatoire ne peut pas avoir de valeur par défaut")
+	}
+
+	f.Required = fs.Required
+	f.Unique = fs.Unique
+	f.Validate = strings.Trim(fs.Validate, ", ")
+	if fs.Default != nil {
+		literal, err := goLiteral(fs.Type, fs.Default)
+		if err != nil {
+			return Field{}, err
+		}
+		f.Default = literal
+	}
+	return f, nil
+}
+
+func (is IndexSpec) index(r *Resource) (Index, error) {
+	if len(is.Fields) == 0 {
+		return Index{}, fmt.Errorf("aucun champ dans fields")
+	}
+	if is.Text && (is.Unique || is.TTL != "") {
+		return Index{}, fmt.Errorf("un index texte ne peut pas être unique ou TTL")
+	}
+
+	index := Index{Unique: is.Unique, Text: is.Text}
+	for _, name := range is.Fields {
+		key := IndexKey{Field: strings.TrimPrefix(name, "-"), Desc: strings.HasPrefix(name, "-")}
+		goType, ok := r.fieldType(key.Field)
+		if !ok {
+			return Index{}, fmt.Errorf("champ %q inconnu", key.Field)
+		}
+		if is.Text && (key.Desc || goType != "string") {
+			return Index{}, fmt.Errorf("index texte: %s n'est pas un champ string", name)
+		}
+		if is.TTL != "" && (goType != "time.Time" || !r.alwaysSet(key.Field)) {
+			// un champ date optionnel vaut l'an 1 quand il est absent, le document serait supprimé aussitôt
+			return Index{}, fmt.Errorf("index TTL: %s n'est pas un champ time.Time obligatoire, createdAt ou updatedAt", name)
+		}
+		index.Keys = append(index.Keys, key)
+	}
+
+	if is.TTL != "" {
+		ttl, err := time.ParseDuration(is.TTL)
+		if err != nil || ttl < time.Second {
+			return Index{}, fmt.Errorf("ttl %q invalide (durée d'au moins 1s attendue, ex: 24h)", is.TTL)
+		}
+		if len(index.Keys) != 1 {
+			return Index{}, fmt.Errorf("un index TTL porte sur un seul champ")
+		}
+		index.TTL = ttl
+	}
+	return index, nil
+}
+
+// goLiteral convertit une valeur par défaut YAML en littéral Go du type du champ
+func goLiteral(goType string, value any) (string, error) {
+	switch goType {
+	case "string":
+		if s, ok := value.(string); ok {
+			return strconv.Quote(s), nil
+		}
+	case "bool":
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+	case "int", "int32", "int64":
+		if n, ok := value.(int); ok {
+			return strconv.Itoa(n), nil
+		}
+	case "float32", "float64":
+		switch n := value.(type) {
+		case int:
+			return strconv.Itoa(n), nil
+		case float64:
+			return strconv.FormatFloat(n, 'g', -1, 64), nil
+		}
+	default:
+		return "", fmt.Errorf("valeur par défaut non supportée pour le type %s", goType)
+	}
+	return "", fmt.Errorf("valeur par défaut %v incompatible avec le type %s", value, goType)
+}
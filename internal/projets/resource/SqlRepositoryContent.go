@@ -0,0 +1,335 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/projets/stage2"
+	"strings"
+)
+
+// SqlSupported vérifie que la ressource peut être générée pour une base SQL:
+// les champs liste et les index texte ou TTL sont propres à MongoDB
+func (r *Resource) SqlSupported() error {
+	for _, f := range r.Fields {
+		if strings.HasPrefix(f.Type, "[]") {
+			return fmt.Errorf("champ %s: type %s non supporté en SQL (MongoDB uniquement)", f.Key, f.Type)
+		}
+	}
+	for i, index := range r.Indexes {
+		if index.Text || index.TTL > 0 {
+			return fmt.Errorf("indexes[%d]: index texte ou TTL non supporté en SQL (MongoDB uniquement)", i)
+		}
+	}
+	return nil
+}
+
+// SqlRepositoryContent repository database/sql de la ressource sur la table Collection (migration SqlMigrationUpContent),
+// les colonnes portent le nom json des champs entre guillemets, ex: "createdAt"
+func SqlRepositoryContent(moduleName string, r *Resource, db stage2.Db) string {
+	// placeholders numérotés à partir de 1 pour chaque requête
+	placeholders := func(from, n int) []string {
+		values := make([]string, n)
+		for i := range values {
+			values[i] = db.Placeholder(from + i)
+		}
+		return values
+	}
+
+	columns := []string{"id"}
+	var fieldColumns, values, targets []string
+	for _, f := range r.Fields {
+		fieldColumns = append(fieldColumns, quoteColumn(f.Key))
+		values = append(values, fmt.Sprintf("%sEntity.%s", r.Var, f.Name))
+		targets = append(targets, "&result."+f.Name)
+	}
+	columns = append(append(columns, fieldColumns...), `"createdAt"`, `"updatedAt"`)
+	targets = append(targets, "&result.CreatedAt", "&result.UpdatedAt")
+	selectFrom := "SELECT " + strings.Join(columns, ", ") + " FROM " + r.Collection
+
+	notDeleted, pageBase := "", ""
+	if r.SoftDelete {
+		notDeleted = ` AND "deletedAt" IS NULL`
+		pageBase = ", `\"deletedAt\" IS NULL`"
+	}
+
+	var finders []string
+	for _, f := range r.UniqueFields() {
+		where := " WHERE " + quoteColumn(f.Key) + " = " + db.Placeholder(1)
+		if r.SoftDelete {
+			where += notDeleted
+		}
+		finders = append(finders, fmt.Sprintf(`
+// FindBy%[3]s récupère un %[2]s par son %[4]s
+func (r *sql%[2]sRepository) FindBy%[3]s(ctx context.Context, %[5]s %[6]s) (*%[1]s.%[2]s, error) {
+	return r.findOne(ctx, %[7]s, %[5]s)
+}
+`, r.Package, r.Name, f.Name, f.Key, f.Param(), f.Type, rawString(selectFrom+where)))
+	}
+
+	insertColumns := append(append([]string{}, fieldColumns...), `"createdAt"`, `"updatedAt"`)
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) RETURNING id",
+		r.Collection, strings.Join(insertColumns, ", "), strings.Join(placeholders(1, len(insertColumns)), ", "))
+
+	var sets []string
+	for i, column := range append(append([]string{}, fieldColumns...), `"updatedAt"`) {
+		sets = append(sets, column+" = "+db.Placeholder(i+1))
+	}
+	update := fmt.Sprintf(`UPDATE %s SET %s WHERE id = %s%s RETURNING "createdAt"`,
+		r.Collection, strings.Join(sets, ", "), db.Placeholder(len(sets)+1), notDeleted)
+
+	deleteQuery, deleteArgs := fmt.Sprintf("DELETE FROM %s WHERE id = %s", r.Collection, db.Placeholder(1)), "key"
+	if r.SoftDelete {
+		deleteQuery = fmt.Sprintf(`UPDATE %s SET "deletedAt" = %s WHERE id = %s%s`, r.Collection, db.Placeholder(1), db.Placeholder(2), notDeleted)
+		deleteArgs = "time.Now().UTC(), key"
+	}
+
+	return gofmt(fmt.Sprintf(`package %[2]srepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"%[1]s/internal/domain/%[2]s"
+	"%[1]s/internal/domain/pagination"
+	"%[1]s/internal/infrastructure/adapter/sqladapter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sql%[3]sRepository repository %[5]s de %[3]s, table %[6]s (migration create_%[6]s)
+type sql%[3]sRepository struct {
+	db *sql.DB
+}
+
+// NewSql%[3]sRepository crée une nouvelle instance du repository %[5]s pour %[3]s
+func NewSql%[3]sRepository(db *sql.DB) %[2]s.%[3]sRepository {
+	return &sql%[3]sRepository{
+		db: db,
+	}
+}
+
+// FindByID récupère un %[3]s par son ID
+func (r *sql%[3]sRepository) FindByID(ctx context.Context, id string) (*%[2]s.%[3]s, error) {
+	key, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		// un ID invalide ne peut correspondre à aucun %[3]s
+		return nil, %[2]s.ErrNotFound
+	}
+	return r.findOne(ctx, %[7]s, key)
+}
+%[8]s
+// FindAll récupère une page de %[3]s selon la pagination, le tri et les filtres de query
+func (r *sql%[3]sRepository) FindAll(ctx context.Context, query pagination.Query) (pagination.Result[*%[2]s.%[3]s], error) {
+	page, err := sqladapter.NewPage(%[6]q, %[9]s, query%[10]s)
+	if err != nil {
+		return pagination.Result[*%[2]s.%[3]s]{}, err
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, page.Count, page.CountArgs...).Scan(&total); err != nil {
+		return pagination.Result[*%[2]s.%[3]s]{}, fmt.Errorf("erreur lors du comptage: %%w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, page.Select, page.SelectArgs...)
+	if err != nil {
+		return pagination.Result[*%[2]s.%[3]s]{}, fmt.Errorf("erreur lors de la récupération: %%w", err)
+	}
+	defer rows.Close()
+
+	results := []*%[2]s.%[3]s{}
+	for rows.Next() {
+		result, err := scan%[3]s(rows)
+		if err != nil {
+			return pagination.Result[*%[2]s.%[3]s]{}, fmt.Errorf("erreur lors du décodage: %%w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Result[*%[2]s.%[3]s]{}, fmt.Errorf("erreur lors du décodage: %%w", err)
+	}
+
+	return pagination.NewResult(results, total, query, func(%[4]sEntity *%[2]s.%[3]s) string { return %[4]sEntity.ID }), nil
+}
+
+// Create crée un nouveau %[3]s, ErrAlreadyExists si la valeur d'un champ unique est déjà utilisée
+func (r *sql%[3]sRepository) Create(ctx context.Context, %[4]sEntity *%[2]s.%[3]s) error {
+	now := time.Now().UTC()
+	var key int64
+	err := r.db.QueryRowContext(ctx, %[11]s, %[12]s, now, now).Scan(&key)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return %[2]s.ErrAlreadyExists
+		}
+		return fmt.Errorf("erreur lors de la création: %%w", err)
+	}
+
+	// Mettre à jour l'ID et les dates de l'entité avec ceux enregistrés
+	%[4]sEntity.ID = strconv.FormatInt(key, 10)
+	%[4]sEntity.CreatedAt, %[4]sEntity.UpdatedAt = now, now
+
+	return nil
+}
+
+// Update met à jour un %[3]s existant
+func (r *sql%[3]sRepository) Update(ctx context.Context, %[4]sEntity *%[2]s.%[3]s) error {
+	key, err := strconv.ParseInt(%[4]sEntity.ID, 10, 64)
+	if err != nil {
+		return %[2]s.ErrNotFound
+	}
+
+	now := time.Now().UTC()
+	var createdAt time.Time
+	err = r.db.QueryRowContext(ctx, %[13]s, %[12]s, now, key).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return %[2]s.ErrNotFound
+	}
+	if err != nil {
+		if isUniqueViolation(err) {
+			return %[2]s.ErrAlreadyExists
+		}
+		return fmt.Errorf("erreur lors de la mise à jour: %%w", err)
+	}
+
+	%[4]sEntity.CreatedAt, %[4]sEntity.UpdatedAt = createdAt, now
+	return nil
+}
+
+// Delete supprime un %[3]s par son ID
+func (r *sql%[3]sRepository) Delete(ctx context.Context, id string) error {
+	key, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return %[2]s.ErrNotFound
+	}
+
+	result, err := r.db.ExecContext(ctx, %[14]s, %[15]s)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression: %%w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return %[2]s.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sql%[3]sRepository) findOne(ctx context.Context, query string, args ...any) (*%[2]s.%[3]s, error) {
+	result, err := scan%[3]s(r.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, %[2]s.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération: %%w", err)
+	}
+	return result, nil
+}
+
+// scan%[3]s lit une ligne des colonnes %[9]s (*sql.Row ou *sql.Rows)
+func scan%[3]s(row interface{ Scan(dest ...any) error }) (*%[2]s.%[3]s, error) {
+	var key int64
+	var result %[2]s.%[3]s
+	if err := row.Scan(&key, %[16]s); err != nil {
+		return nil, err
+	}
+	result.ID = strconv.FormatInt(key, 10)
+	return &result, nil
+}
+
+// isUniqueViolation erreur de contrainte unique (PostgreSQL 23505, SQLite UNIQUE constraint failed)
+func isUniqueViolation(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "23505") || strings.Contains(message, "UNIQUE constraint failed")
+}
+`, moduleName, r.Package, r.Name, r.Var, db.Label(), r.Collection,
+		rawString(selectFrom+" WHERE id = "+db.Placeholder(1)+notDeleted), strings.Join(finders, ""),
+		rawString(strings.Join(columns, ", ")), pageBase,
+		rawString(insert), strings.Join(values, ", "), rawString(update),
+		rawString(deleteQuery), deleteArgs, strings.Join(targets, ", ")))
+}
+
+// SqlMigrationUpContent création de la table Collection: colonnes des champs (UNIQUE pour les champs uniques),
+// dates createdAt, updatedAt et deletedAt (suppression logique) puis index de la spec
+func SqlMigrationUpContent(r *Resource, db stage2.Db) string {
+	id, timestamp := "id BIGSERIAL PRIMARY KEY", "TIMESTAMPTZ"
+	if db == stage2.DbSqlite {
+		id, timestamp = "id INTEGER PRIMARY KEY AUTOINCREMENT", "TIMESTAMP"
+	}
+
+	lines := []string{id}
+	for _, f := range r.Fields {
+		line := quoteColumn(f.Key) + " " + sqlType(f.Type, db) + " NOT NULL"
+		if f.Unique {
+			line += " UNIQUE"
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, `"createdAt" `+timestamp+" NOT NULL", `"updatedAt" `+timestamp+" NOT NULL")
+	if r.SoftDelete {
+		lines = append(lines, `"deletedAt" `+timestamp)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n    %s\n);\n", r.Collection, strings.Join(lines, ",\n    "))
+	for _, index := range r.Indexes {
+		names := []string{r.Collection}
+		var keys []string
+		for _, key := range index.Keys {
+			names = append(names, key.Field)
+			order := ""
+			if key.Desc {
+				order = " DESC"
+			}
+			keys = append(keys, quoteColumn(key.Field)+order)
+		}
+		unique := ""
+		if index.Unique {
+			unique = "UNIQUE "
+		}
+		fmt.Fprintf(&b, "CREATE %sINDEX IF NOT EXISTS %s ON %s (%s);\n",
+			unique, quoteColumn(strings.Join(append(names, "idx"), "_")), r.Collection, strings.Join(keys, ", "))
+	}
+	return b.String()
+}
+
+// SqlMigrationDownContent suppression de la table Collection
+func SqlMigrationDownContent(r *Resource) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", r.Collection)
+}
+
+// sqlType type de colonne d'un type Go
+func sqlType(goType string, db stage2.Db) string {
+	sqlite := db == stage2.DbSqlite
+	switch goType {
+	case "bool":
+		return "BOOLEAN"
+	case "int", "int64":
+		if sqlite {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case "int32":
+		return "INTEGER"
+	case "float32", "float64":
+		if sqlite {
+			return "REAL"
+		}
+		return "DOUBLE PRECISION"
+	case "time.Time":
+		if sqlite {
+			return "TIMESTAMP"
+		}
+		return "TIMESTAMPTZ"
+	}
+	return "TEXT"
+}
+
+// quoteColumn nom de colonne entre guillemets, la casse des champs camelCase est conservée
+func quoteColumn(name string) string {
+	return `"` + name + `"`
+}
+
+// rawString littéral Go entre backquotes d'une requête SQL
+func rawString(query string) string {
+	return "`" + query + "`"
+}
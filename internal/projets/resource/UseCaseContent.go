@@ -0,0 +1,110 @@
+package resource
+
+import (
+	"fmt"
+	"strings"
+)
+
+func UseCaseContent(moduleName string, r *Resource) string {
+	unique := r.UniqueFields()
+
+	imports := "\t\"context\"\n"
+	check, checkCall := "", ""
+	if len(unique) > 0 {
+		imports += "\t\"errors\"\n\t\"fmt\"\n"
+		checkCall = fmt.Sprintf(`
+	if err := uc.checkUnique(ctx, %sEntity); err != nil {
+		return nil, err
+	}
+`, r.Var)
+
+		var checks []string
+		for _, f := range unique {
+			value := fmt.Sprintf("%sEntity.%s", r.Var, f.Name)
+			block := fmt.Sprintf(`
+	if existing, err := uc.repo.FindBy%[3]s(ctx, %[5]s); err == nil && existing.ID != %[2]sEntity.ID {
+		return fmt.Errorf("%%w: %[4]s %%v", %[1]s.ErrAlreadyExists, %[5]s)
+	} else if err != nil && !errors.Is(err, %[1]s.ErrNotFound) {
+		return err
+	}
+`, r.Package, r.Var, f.Name, f.Key, value)
+			// un champ optionnel non renseigné n'est pas contrôlé
+			if !f.Required {
+				block = fmt.Sprintf("\n\tif %s {%s\t}\n", f.notZero(value), block)
+			}
+			checks = append(checks, block)
+		}
+		check = fmt.Sprintf(`
+// checkUnique vérifie qu'aucun autre %[2]s n'utilise la valeur d'un champ unique
+func (uc *%[2]sUseCase) checkUnique(ctx context.Context, %[3]sEntity *%[1]s.%[2]s) error {%[4]s
+	return nil
+}
+`, r.Package, r.Name, r.Var, strings.Join(checks, ""))
+	}
+
+	return gofmt(fmt.Sprintf(`package %[2]susecase
+
+import (
+%[5]s	"%[1]s/internal/domain/%[2]s"
+	"%[1]s/internal/domain/pagination"
+)
+
+// %[3]sUseCase gère la logique métier pour les %[3]s
+type %[3]sUseCase struct {
+	repo %[2]s.%[3]sRepository
+}
+
+// New%[3]sUseCase crée une nouvelle instance du use case
+func New%[3]sUseCase(repo %[2]s.%[3]sRepository) *%[3]sUseCase {
+	return &%[3]sUseCase{
+		repo: repo,
+	}
+}
+
+// GetByID récupère un %[3]s par son ID
+func (uc *%[3]sUseCase) GetByID(ctx context.Context, id string) (*%[2]s.%[3]s, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// GetAll récupère une page de %[3]s
+func (uc *%[3]sUseCase) GetAll(ctx context.Context, query pagination.Query) (pagination.Result[*%[2]s.%[3]s], error) {
+	return uc.repo.FindAll(ctx, query)
+}
+
+// Create crée un nouveau %[3]s
+func (uc *%[3]sUseCase) Create(ctx context.Context, %[4]sEntity *%[2]s.%[3]s) (*%[2]s.%[3]s, error) {%[6]s
+	if err := uc.repo.Create(ctx, %[4]sEntity); err != nil {
+		return nil, err
+	}
+
+	return %[4]sEntity, nil
+}
+
+// Update enregistre les modifications d'un %[3]s existant
+func (uc *%[3]sUseCase) Update(ctx context.Context, %[4]sEntity *%[2]s.%[3]s) (*%[2]s.%[3]s, error) {%[6]s
+	if err := uc.repo.Update(ctx, %[4]sEntity); err != nil {
+		return nil, err
+	}
+
+	return %[4]sEntity, nil
+}
+
+// Delete supprime un %[3]s
+func (uc *%[3]sUseCase) Delete(ctx context.Context, id string) error {
+	return uc.repo.Delete(ctx, id)
+}
+%[7]s`, moduleName, r.Package, r.Name, r.Var, imports, checkCall, check))
+}
+
+// notZero condition vraie quand la valeur du champ est renseignée
+func (f Field) notZero(value string) string {
+	switch f.Type {
+	case "string":
+		return value + ` != ""`
+	case "bool":
+		return value
+	case "time.Time":
+		return "!" + value + ".IsZero()"
+	}
+	return value + " != 0"
+}
@@ -0,0 +1,39 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/projets/stage2"
+	"github.com/nsevendev/starter/internal/wiring"
+)
+
+// Registration câblage de la ressource dans getControllers() du main.go (schéma -> repository -> use case -> controller),
+// en SQL la table est créée par la migration de la ressource et le repository utilise sqlDatabase
+func Registration(moduleName string, r *Resource, db stage2.Db) wiring.Registration {
+	imports := []string{
+		fmt.Sprintf("%s/internal/domain/%s", moduleName, r.Package),
+		fmt.Sprintf("%s/internal/infrastructure/repository/%srepository", moduleName, r.Package),
+		fmt.Sprintf("%s/internal/application/usecase/%susecase", moduleName, r.Package),
+		fmt.Sprintf("%s/internal/application/controller/%scontroller", moduleName, r.Package),
+	}
+	statements := []string{
+		fmt.Sprintf("ensureSchemas(mongoDatabase, %s.Schema)", r.Package),
+		fmt.Sprintf("%[1]sRepo := %[2]srepository.NewMongo%[3]sRepository(mongoDatabase)", r.Var, r.Package, r.Name),
+	}
+	if db.Sql() {
+		imports = imports[1:]
+		statements = []string{
+			fmt.Sprintf("%[1]sRepo := %[2]srepository.NewSql%[3]sRepository(sqlDatabase)", r.Var, r.Package, r.Name),
+		}
+	}
+
+	return wiring.Registration{
+		Comment: r.Name,
+		Imports: imports,
+		Statements: append(statements,
+			fmt.Sprintf("%[1]sUseCase := %[2]susecase.New%[3]sUseCase(%[1]sRepo)", r.Var, r.Package, r.Name),
+		),
+		Controllers: []string{
+			fmt.Sprintf("%scontroller.New(%sUseCase)", r.Package, r.Var),
+		},
+	}
+}
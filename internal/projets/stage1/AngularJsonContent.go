@@ -1,10 +1,9 @@
 package stage1
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
+	"github.com/nsevendev/starter/internal/jsonpatch"
 )
 
 // ServeOptions options pour la commande "ng serve"
@@ -35,71 +34,85 @@ type PatchOptions struct {
 // - modifie serve.configurations.production.buildTarget
 // - ajoute "cli.analytics": false si demandé
 //
-// Ne modifie que les clés indiquées, les autres restent intactes.
+// Ne modifie que les clés indiquées, les autres restent intactes (ordre des clés et indentation conservés).
 func PatchAngularJSON(opts PatchOptions) error {
-	data, err := os.ReadFile(opts.AngularJSONPath)
+	doc, err := jsonpatch.ParseFile(opts.AngularJSONPath)
 	if err != nil {
-		return fmt.Errorf("lecture %s: %w", opts.AngularJSONPath, err)
+		return err
 	}
 
-	var root map[string]any
-	if err := json.Unmarshal(data, &root); err != nil {
-		return fmt.Errorf("parse JSON: %w", err)
-	}
-
-	projects, ok := root["projects"].(map[string]any)
-	if !ok {
+	if _, err := doc.Keys("/projects"); err != nil {
 		return errors.New(`clé "projects" absente ou invalide`)
 	}
 
 	// récup projet (et éventuellement renommer la clé)
 	projKey := opts.ProjectOldName
-	if _, exists := projects[projKey]; !exists {
+	if !doc.Has(jsonpatch.Pointer("projects", projKey)) {
 		// Si l’ancien nom n’existe pas, peut-être que le fichier a déjà le nouveau ?
-		if _, exists2 := projects[opts.ProjectNewName]; exists2 {
+		if doc.Has(jsonpatch.Pointer("projects", opts.ProjectNewName)) {
 			projKey = opts.ProjectNewName
 		} else {
 			return fmt.Errorf("projet %q introuvable dans projects", opts.ProjectOldName)
 		}
 	}
 
-	projVal, ok := projects[projKey].(map[string]any)
-	if !ok {
+	if _, err := doc.Keys(jsonpatch.Pointer("projects", projKey)); err != nil {
 		return fmt.Errorf("projects.%s n’est pas un objet", projKey)
 	}
 
 	// renommer la clé si nécessaire
 	if projKey != opts.ProjectNewName {
-		projects[opts.ProjectNewName] = projVal
-		delete(projects, projKey)
+		if err := doc.Apply(jsonpatch.Move(jsonpatch.Pointer("projects", projKey), jsonpatch.Pointer("projects", opts.ProjectNewName))); err != nil {
+			return fmt.Errorf("renommage du projet: %w", err)
+		}
 		projKey = opts.ProjectNewName
 	}
 
+	architect := []string{"projects", projKey, "architect"}
+
 	// vers architect/build/options
-	architect := mustObj(projVal, "architect")
-	build := mustObj(architect, "build")
-	buildOptions := mustObj(build, "options")
+	buildOptions := append(append([]string{}, architect...), "build", "options")
+	if err := ensureObject(doc, buildOptions...); err != nil {
+		return err
+	}
 
 	// outputPath
 	if opts.OutputPath != "" {
-		buildOptions["outputPath"] = opts.OutputPath
+		if err := doc.Apply(jsonpatch.Add(jsonpatch.Pointer(append(buildOptions, "outputPath")...), opts.OutputPath)); err != nil {
+			return err
+		}
 	}
 
 	// vers budgets anyComponentStyle (production)
-	configs := mustObj(build, "configurations")
-	prod := mustObj(configs, "production")
-	budgets := mustArr(prod, "budgets")
+	prod := append(append([]string{}, architect...), "build", "configurations", "production")
+	if err := ensureObject(doc, prod...); err != nil {
+		return err
+	}
+	budgetsPath := jsonpatch.Pointer(append(prod, "budgets")...)
+	var budgets []any
+	if err := doc.Decode(budgetsPath, &budgets); err != nil {
+		// absent ou d'un autre type: tableau vide
+		if err := doc.Apply(jsonpatch.Add(budgetsPath, []any{})); err != nil {
+			return err
+		}
+		budgets = nil
+	}
 
 	// cherche un budget type anyComponentStyle ; sinon en crée un
 	foundStyle := false
 	for i := range budgets {
 		if b, ok := budgets[i].(map[string]any); ok {
 			if b["type"] == "anyComponentStyle" {
+				budgetPath := fmt.Sprintf("%s/%d", budgetsPath, i)
 				if opts.BudgetStyleWarn != "" {
-					b["maximumWarning"] = opts.BudgetStyleWarn
+					if err := doc.Apply(jsonpatch.Add(budgetPath+"/maximumWarning", opts.BudgetStyleWarn)); err != nil {
+						return err
+					}
 				}
 				if opts.BudgetStyleErr != "" {
-					b["maximumError"] = opts.BudgetStyleErr
+					if err := doc.Apply(jsonpatch.Add(budgetPath+"/maximumError", opts.BudgetStyleErr)); err != nil {
+						return err
+					}
 				}
 				foundStyle = true
 				break
@@ -107,101 +120,87 @@ func PatchAngularJSON(opts PatchOptions) error {
 		}
 	}
 	if !foundStyle && (opts.BudgetStyleWarn != "" || opts.BudgetStyleErr != "") {
-		newB := map[string]any{
-			"type": "anyComponentStyle",
-		}
-		if opts.BudgetStyleWarn != "" {
-			newB["maximumWarning"] = opts.BudgetStyleWarn
+		newB := budget{
+			Type:           "anyComponentStyle",
+			MaximumWarning: opts.BudgetStyleWarn,
+			MaximumError:   opts.BudgetStyleErr,
 		}
-		if opts.BudgetStyleErr != "" {
-			newB["maximumError"] = opts.BudgetStyleErr
+		if err := doc.Apply(jsonpatch.Add(budgetsPath+"/-", newB)); err != nil {
+			return err
 		}
-		budgets = append(budgets, newB)
-		prod["budgets"] = budgets
 	}
 
 	// serve options + buildTarget
-	serve := mustObj(architect, "serve")
+	serve := append(append([]string{}, architect...), "serve")
 	// options
 	if opts.Serve != nil {
-		serveOpts := mustObj(serve, "options")
+		serveOpts := append(append([]string{}, serve...), "options")
+		if err := ensureObject(doc, serveOpts...); err != nil {
+			return err
+		}
+		var ops []jsonpatch.Operation
 		if opts.Serve.Host != "" {
-			serveOpts["host"] = opts.Serve.Host
+			ops = append(ops, jsonpatch.Add(jsonpatch.Pointer(append(serveOpts, "host")...), opts.Serve.Host))
 		}
 		if opts.Serve.Port > 0 {
-			serveOpts["port"] = opts.Serve.Port
+			ops = append(ops, jsonpatch.Add(jsonpatch.Pointer(append(serveOpts, "port")...), opts.Serve.Port))
 		}
 		if opts.Serve.Poll > 0 {
-			serveOpts["poll"] = opts.Serve.Poll
+			ops = append(ops, jsonpatch.Add(jsonpatch.Pointer(append(serveOpts, "poll")...), opts.Serve.Poll))
 		}
 		if len(opts.Serve.AllowedHosts) > 0 {
-			// convert []string -> []any pour JSON générique
-			hosts := make([]any, 0, len(opts.Serve.AllowedHosts))
-			for _, h := range opts.Serve.AllowedHosts {
-				hosts = append(hosts, h)
-			}
-			serveOpts["allowedHosts"] = hosts
+			ops = append(ops, jsonpatch.Add(jsonpatch.Pointer(append(serveOpts, "allowedHosts")...), opts.Serve.AllowedHosts))
+		}
+		if err := doc.Apply(ops...); err != nil {
+			return err
 		}
 	}
 	// configurations.buildTarget
-	serveCfg := mustObj(serve, "configurations")
-	serveCfg["production"] = map[string]any{"buildTarget": fmt.Sprintf("%s:build:production", projKey)}
-	serveCfg["development"] = map[string]any{"buildTarget": fmt.Sprintf("%s:build:development", projKey)}
+	serveCfg := append(append([]string{}, serve...), "configurations")
+	if err := ensureObject(doc, serveCfg...); err != nil {
+		return err
+	}
+	if err := doc.Apply(
+		jsonpatch.Add(jsonpatch.Pointer(append(serveCfg, "production")...), map[string]any{"buildTarget": fmt.Sprintf("%s:build:production", projKey)}),
+		jsonpatch.Add(jsonpatch.Pointer(append(serveCfg, "development")...), map[string]any{"buildTarget": fmt.Sprintf("%s:build:development", projKey)}),
+	); err != nil {
+		return err
+	}
 
 	// cli.analytics
 	if opts.DisableAnalytics {
-		cliObj, _ := root["cli"].(map[string]any)
-		if cliObj == nil {
-			cliObj = map[string]any{}
+		if err := ensureObject(doc, "cli"); err != nil {
+			return err
+		}
+		if err := doc.Apply(jsonpatch.Add("/cli/analytics", false)); err != nil {
+			return err
 		}
-		cliObj["analytics"] = false
-		root["cli"] = cliObj
 	}
 
 	// ecriture
-	out, err := json.MarshalIndent(root, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal JSON: %w", err)
-	}
-	if err := os.WriteFile(opts.AngularJSONPath, out, 0o644); err != nil {
-		return fmt.Errorf("écriture %s: %w", opts.AngularJSONPath, err)
-	}
-	return nil
+	return doc.WriteFile(opts.AngularJSONPath)
 }
 
-// mustObj retourne m[key] en tant que map[string]any.
-// Si la clé n'existe pas, elle est créée avec un objet vide.
-// Si la clé existe mais n'est pas un objet, elle est remplacée par un objet vide.
-func mustObj(m map[string]any, key string) map[string]any {
-	v, ok := m[key]
-	if !ok || v == nil {
-		n := map[string]any{}
-		m[key] = n
-		return n
-	}
-	if mm, ok := v.(map[string]any); ok {
-		return mm
-	}
-	// si le type est mauvais, on remplace par un objet vide (safe pour patcher)
-	n := map[string]any{}
-	m[key] = n
-	return n
+// budget budget angular, les champs sont écrits dans cet ordre
+type budget struct {
+	Type           string `json:"type"`
+	MaximumWarning string `json:"maximumWarning,omitempty"`
+	MaximumError   string `json:"maximumError,omitempty"`
 }
 
-// mustArr retourne m[key] en tant que []any.
-// Si la clé n'existe pas, elle est créée avec un tableau vide.
-// Si la clé existe mais n'est pas un tableau, elle est remplacée par un tableau vide.
-func mustArr(m map[string]any, key string) []any {
-	v, ok := m[key]
-	if !ok || v == nil {
-		n := []any{}
-		m[key] = n
-		return n
-	}
-	if arr, ok := v.([]any); ok {
-		return arr
-	}
-	n := []any{}
-	m[key] = n
-	return n
+// ensureObject garantit un objet à chaque niveau du chemin.
+// Si une clé n'existe pas, elle est créée avec un objet vide.
+// Si une clé existe mais n'est pas un objet, elle est remplacée par un objet vide.
+func ensureObject(doc *jsonpatch.Document, tokens ...string) error {
+	for i := range tokens {
+		path := jsonpatch.Pointer(tokens[:i+1]...)
+		if _, err := doc.Keys(path); err == nil {
+			continue
+		}
+		// Add remplace la valeur si la clé existe déjà avec un autre type
+		if err := doc.Apply(jsonpatch.Add(path, map[string]any{})); err != nil {
+			return fmt.Errorf("création de %s: %w", path, err)
+		}
+	}
+	return nil
 }
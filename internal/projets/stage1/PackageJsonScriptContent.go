@@ -1,42 +1,17 @@
 package stage1
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
+	"github.com/nsevendev/starter/internal/packagejson"
 )
 
-// ReplacePackageJSONScripts remplace la clé "scripts" dans un package.json
-// par la map fournie (remplacement complet, pas de fusion).
-// Ex: ReplacePackageJSONScripts("package.json", map[string]string{"start": "node server.js"})
-func ReplacePackageJSONScripts(path string, newScripts map[string]string) error {
-	// lire package.json
-	data, err := os.ReadFile(path)
+// MergePackageJSONScripts fusionne les scripts clé par clé selon leur policy
+// (set, keep-existing, remove), les scripts non listés sont conservés.
+// Ex: MergePackageJSONScripts("package.json", []packagejson.Entry{packagejson.Set("start", "node server.js")})
+func MergePackageJSONScripts(path string, scripts []packagejson.Entry) (*packagejson.Report, error) {
+	report, err := packagejson.MergeFile(path, packagejson.Merge{Scripts: scripts})
 	if err != nil {
-		return fmt.Errorf("lecture %s: %w", path, err)
+		return nil, fmt.Errorf("modification des scripts: %w", err)
 	}
-
-	// parser en map générique pour ne toucher qu'à "scripts"
-	var pkg map[string]any
-	if err := json.Unmarshal(data, &pkg); err != nil {
-		return fmt.Errorf("parse JSON: %w", err)
-	}
-
-	// remplacer ENTIEREMENT la clé "scripts"
-	scripts := make(map[string]any, len(newScripts))
-	for k, v := range newScripts {
-		scripts[k] = v
-	}
-	pkg["scripts"] = scripts
-
-	// réécrire le script
-	out, err := json.MarshalIndent(pkg, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal: %w", err)
-	}
-	out = append(out, '\n')
-	if err := os.WriteFile(path, out, 0o644); err != nil {
-		return fmt.Errorf("écriture %s: %w", path, err)
-	}
-	return nil
+	return report, nil
 }
@@ -0,0 +1,95 @@
+package stage2
+
+import "fmt"
+
+func AdminCmdContent(moduleName string, db Db) string {
+	imports := []string{
+		`"context"`,
+		`"errors"`,
+		`"fmt"`,
+		`"github.com/nsevenpack/env/env"`,
+		fmt.Sprintf("%q", moduleName+"/internal/application/gateway/loggateway"),
+		fmt.Sprintf("%q", moduleName+"/internal/domain/user"),
+		fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/loggeradapter"),
+		fmt.Sprintf("%q", moduleName+"/internal/infrastructure/repository/userrepository"),
+		`"os"`,
+		`"slices"`,
+		`"strings"`,
+	}
+	adapter := "mongoadapter"
+	newAdapter := `mongoadapter.New(env.Get("DB_URI"), env.Get("DB_NAME"), logger)`
+	client := `database := dbAdapter.GetClient().(*mongo.Client).Database(env.Get("DB_NAME"))`
+	repo := "userrepository.NewMongoUserRepository(database)"
+	if db.Sql() {
+		imports = append(imports, `"database/sql"`)
+		adapter = "sqladapter"
+		newAdapter = `sqladapter.New(env.Get("DB_URI"), logger)`
+		client = `database := dbAdapter.GetClient().(*sql.DB)`
+		repo = "userrepository.NewSqlUserRepository(database)"
+	} else {
+		imports = append(imports, `"go.mongodb.org/mongo-driver/mongo"`)
+	}
+	imports = append(imports, fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/"+adapter))
+
+	return fmt.Sprintf(`package main
+
+%[1]s
+
+const usage = `+"`"+`usage: go run ./cmd/admin <commande>
+  promote <email>  donne le rôle admin à un utilisateur déjà inscrit, ex: promote admin@example.com
+`+"`"+`
+
+// admin gère les administrateurs hors de l'api: l'inscription ne donne jamais le rôle admin,
+// le premier admin est créé en inscrivant l'utilisateur puis en le promouvant (dans le conteneur api: make admin-promote email=...)
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+	logger := loggeradapter.New(env.Get("APP_ENV"))
+
+	if err := run(context.Background(), os.Args[1], os.Args[2:], logger); err != nil {
+		logger.Ef("Admin : %%v", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string, logger loggateway.Logger) error {
+	if command != "promote" {
+		fmt.Print(usage)
+		return fmt.Errorf("commande inconnue %%q", command)
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("email absent, ex: promote admin@example.com")
+	}
+
+	dbAdapter := %[2]s
+	if err := dbAdapter.Connect(ctx); err != nil {
+		return err
+	}
+	defer dbAdapter.Disconnect(ctx)
+	%[3]s
+	repo := %[4]s
+
+	userEntity, err := repo.FindByEmail(ctx, args[0])
+	if errors.Is(err, user.ErrNotFound) {
+		return fmt.Errorf("aucun utilisateur inscrit avec l'email %%q, inscription préalable par /auth/register", args[0])
+	}
+	if err != nil {
+		return err
+	}
+	if slices.Contains(userEntity.Roles, user.RoleAdmin) {
+		logger.If("%%s a déjà le rôle admin", userEntity.Email)
+		return nil
+	}
+
+	roles := append(slices.Clone(userEntity.Roles), user.RoleAdmin)
+	if err := repo.UpdateRoles(ctx, userEntity.ID, roles); err != nil {
+		return err
+	}
+	// les rôles sont dans le token d'accès, pris en compte au prochain renouvellement ou à la prochaine connexion
+	logger.If("%%s promu admin, rôles : %%s", userEntity.Email, strings.Join(roles, ", "))
+	return nil
+}
+`, importBlock(imports), newAdapter, client, repo)
+}
@@ -2,7 +2,14 @@ package stage2
 
 import "fmt"
 
-func ApiDockerfileContent() string {
+func ApiDockerfileContent(opts Options) string {
+	buildAdmin, copyAdmin, binAdmin := "", "", ""
+	if opts.Auth {
+		buildAdmin = "RUN CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -o dist/admin ./cmd/admin\n"
+		copyAdmin = "# promotion du premier admin (make admin-promote)\nCOPY --from=build /app/dist/admin /app/admin\n"
+		binAdmin = " /app/admin"
+	}
+
 	return fmt.Sprintf(`FROM golang:1.24.4-bookworm AS base
 RUN apt-get update && apt-get install -y --no-install-recommends \
     git \
@@ -10,8 +17,6 @@ RUN apt-get update && apt-get install -y --no-install-recommends \
  && rm -rf /var/lib/apt/lists/*
 RUN go install github.com/air-verse/air@v1.61.5
 RUN go install github.com/swaggo/swag/cmd/swag@latest
-RUN go install github.com/nsevenpack/mignosql/cmd/migrationcreate@latest
-RUN go install github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@latest
 RUN mkdir -p /app/tmp/air
 RUN mkdir -p /app/tmp/air/api
 WORKDIR /app
@@ -31,7 +36,8 @@ WORKDIR /app
 COPY . .
 RUN swag init -o docs -g cmd/${SERVICE}/main.go --parseInternal --pd
 RUN CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -o dist/${SERVICE} ./cmd/${SERVICE}
-CMD ["sh", "-c", "ls -l /app/dist/${SERVICE}"]
+RUN CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build -o dist/migrate ./cmd/migrate
+%sCMD ["sh", "-c", "ls -l /app/dist/${SERVICE}"]
 
 FROM golang:1.24.4-bookworm AS runtime-base
 WORKDIR /app
@@ -40,14 +46,16 @@ RUN apt-get update && apt-get install -y --no-install-recommends ca-certificates
 ARG SERVICE=api
 ENV SERVICE=${SERVICE}
 COPY --from=build /app/dist/${SERVICE} /app/application
-COPY --from=build /app/go.mod ./
+# migrations embarquées dans le binaire (make migrate-up, migrate-down, migrate-status)
+COPY --from=build /app/dist/migrate /app/migrate
+%sCOPY --from=build /app/go.mod ./
 COPY --from=build /app/go.sum ./
 COPY --from=build /app/internal ./internal
 COPY go.mod .
-RUN chmod +x /app/application
+RUN chmod +x /app/application /app/migrate%s
 CMD ["./application"]
 
 FROM runtime-base AS prod
 FROM runtime-base AS preprod
-`)
+`, buildAdmin, copyAdmin, binAdmin)
 }
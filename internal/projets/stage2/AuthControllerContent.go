@@ -0,0 +1,264 @@
+package stage2
+
+import "fmt"
+
+func AuthControllerContent(moduleName string) string {
+	return fmt.Sprintf(`package authcontroller
+
+import (
+	"%[1]s/internal/application/gateway/httpgateway"
+	"%[1]s/internal/application/usecase/authusecase"
+	"%[1]s/internal/domain/user"
+)
+
+// TokenResponse tokens retournés par Login et Refresh
+// les réponses sont déclarées dans le package du controller pour que swag les trouve depuis chaque fichier
+type TokenResponse = authusecase.TokenPair
+
+// UserResponse utilisateur retourné par Register, Me et UpdateRoles
+type UserResponse = user.User
+
+type AuthController struct {
+	useCase   *authusecase.AuthUseCase
+	prefixUrl string
+}
+
+// New crée une nouvelle instance du controller Auth
+func New(useCase *authusecase.AuthUseCase) *AuthController {
+	return &AuthController{
+		useCase:   useCase,
+		prefixUrl: "/auth",
+	}
+}
+
+// RegisterRoutes enregistre les routes du controller
+func (c *AuthController) RegisterRoutes(r httpgateway.Router) {
+	r.Handle("POST", c.prefixUrl+"/register", c.Register)
+	r.Handle("POST", c.prefixUrl+"/login", c.Login)
+	r.Handle("POST", c.prefixUrl+"/refresh", c.Refresh)
+	r.Handle("GET", c.prefixUrl+"/me", c.Me, httpgateway.Authenticated())
+	r.Handle("PUT", c.prefixUrl+"/users/:id/roles", c.UpdateRoles, httpgateway.Roles(user.RoleAdmin))
+}
+`, moduleName)
+}
+
+func AuthDtoContent() string {
+	return `package authcontroller
+
+// RegisterRequest inscription d'un utilisateur
+type RegisterRequest struct {
+	Email    string ` + "`json:\"email\" binding:\"required,email,max=254\"`" + `
+	Password string ` + "`json:\"password\" binding:\"required,min=8,max=72\"`" + `
+}
+
+// LoginRequest connexion d'un utilisateur
+type LoginRequest struct {
+	Email    string ` + "`json:\"email\" binding:\"required,email\"`" + `
+	Password string ` + "`json:\"password\" binding:\"required\"`" + `
+}
+
+// RefreshRequest renouvellement des tokens
+type RefreshRequest struct {
+	RefreshToken string ` + "`json:\"refreshToken\" binding:\"required\"`" + `
+}
+
+// UpdateRolesRequest remplacement des rôles d'un utilisateur
+type UpdateRolesRequest struct {
+	Roles []string ` + "`json:\"roles\" binding:\"required,min=1,dive,required\"`" + `
+}
+`
+}
+
+func AuthRegisterContent(moduleName string) string {
+	return fmt.Sprintf(`package authcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+)
+
+// Register inscrit un utilisateur
+// @Summary Inscription
+// @Description Crée un utilisateur avec son email et son mot de passe (8 caractères minimum)
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body RegisterRequest true "Email et mot de passe"
+// @Success 201 {object} UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/register [post]
+func (c *AuthController) Register(ctx httpgateway.Context) {
+	var req RegisterRequest
+	if !ctx.BindJSON(&req) {
+		return
+	}
+
+	userEntity, err := c.useCase.Register(ctx.Request().Context(), req.Email, req.Password)
+	if err != nil {
+		ctx.Error("Erreur lors de l'inscription", err)
+		return
+	}
+
+	ctx.Created("Utilisateur créé", userEntity)
+}
+`, moduleName)
+}
+
+func AuthLoginContent(moduleName string) string {
+	return fmt.Sprintf(`package authcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+)
+
+// Login connecte un utilisateur
+// @Summary Connexion
+// @Description Retourne un token d'accès (15 minutes) et un token de renouvellement (7 jours)
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body LoginRequest true "Email et mot de passe"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/login [post]
+func (c *AuthController) Login(ctx httpgateway.Context) {
+	var req LoginRequest
+	if !ctx.BindJSON(&req) {
+		return
+	}
+
+	tokens, err := c.useCase.Login(ctx.Request().Context(), req.Email, req.Password)
+	if err != nil {
+		ctx.Error("Erreur lors de la connexion", err)
+		return
+	}
+
+	ctx.Success("Connexion réussie", tokens)
+}
+`, moduleName)
+}
+
+func AuthRefreshContent(moduleName string) string {
+	return fmt.Sprintf(`package authcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+)
+
+// Refresh renouvelle les tokens
+// @Summary Renouvellement des tokens
+// @Description Retourne de nouveaux tokens à partir d'un token de renouvellement valide
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body RefreshRequest true "Token de renouvellement"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/refresh [post]
+func (c *AuthController) Refresh(ctx httpgateway.Context) {
+	var req RefreshRequest
+	if !ctx.BindJSON(&req) {
+		return
+	}
+
+	tokens, err := c.useCase.Refresh(ctx.Request().Context(), req.RefreshToken)
+	if err != nil {
+		ctx.Error("Erreur lors du renouvellement", err)
+		return
+	}
+
+	ctx.Success("Tokens renouvelés", tokens)
+}
+`, moduleName)
+}
+
+func AuthMeContent(moduleName string) string {
+	return fmt.Sprintf(`package authcontroller
+
+import (
+	"errors"
+	"%s/internal/application/gateway/httpgateway"
+	"%s/internal/domain/user"
+)
+
+// Me retourne l'utilisateur authentifié
+// @Summary Utilisateur connecté
+// @Description Retourne l'utilisateur du token d'accès
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} UserResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/me [get]
+func (c *AuthController) Me(ctx httpgateway.Context) {
+	authUser, ok := ctx.User()
+	if !ok {
+		ctx.Unauthorized("Authentification requise", "utilisateur absent du contexte")
+		return
+	}
+
+	userEntity, err := c.useCase.GetUser(ctx.Request().Context(), authUser.ID)
+	// un token valide dont l'utilisateur a été supprimé n'authentifie plus personne
+	if errors.Is(err, user.ErrNotFound) {
+		ctx.Unauthorized("Authentification requise", err.Error())
+		return
+	}
+	if err != nil {
+		ctx.Error("Erreur lors de la récupération de l'utilisateur", err)
+		return
+	}
+
+	ctx.Success("Utilisateur connecté", userEntity)
+}
+`, moduleName, moduleName)
+}
+
+func AuthUpdateRolesContent(moduleName string) string {
+	return fmt.Sprintf(`package authcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+)
+
+// UpdateRoles remplace les rôles d'un utilisateur
+// @Summary Rôles d'un utilisateur
+// @Description Remplace les rôles d'un utilisateur (rôle admin requis), pris en compte au prochain renouvellement de ses tokens
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID de l'utilisateur"
+// @Param body body UpdateRolesRequest true "Nouveaux rôles"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/users/{id}/roles [put]
+func (c *AuthController) UpdateRoles(ctx httpgateway.Context) {
+	var req UpdateRolesRequest
+	if !ctx.BindJSON(&req) {
+		return
+	}
+
+	userEntity, err := c.useCase.UpdateRoles(ctx.Request().Context(), ctx.Param("id"), req.Roles)
+	if err != nil {
+		ctx.Error("Erreur lors de la mise à jour des rôles", err)
+		return
+	}
+
+	ctx.Success("Rôles mis à jour", userEntity)
+}
+`, moduleName)
+}
@@ -0,0 +1,75 @@
+package stage2
+
+import "fmt"
+
+func AuthMiddlewareContent(moduleName string) string {
+	return fmt.Sprintf(`package authmiddleware
+
+import (
+	"%[1]s/internal/application/gateway/httpgateway"
+	"%[1]s/internal/application/gateway/tokengateway"
+	"%[1]s/internal/domain/user"
+	"strings"
+)
+
+// AuthMiddleware vérifie le token d'accès (Authorization: Bearer <token>) des routes protégées
+type AuthMiddleware struct {
+	tokens tokengateway.Tokens
+}
+
+// New crée une nouvelle instance du middleware d'authentification
+func New(tokens tokengateway.Tokens) *AuthMiddleware {
+	return &AuthMiddleware{
+		tokens: tokens,
+	}
+}
+
+// Authenticate vérifie le token d'accès et enregistre l'utilisateur authentifié dans le Context (ctx.User())
+// avec ses rôles et les permissions de ses rôles, répond 401 et retourne false sans token d'accès valide.
+// Utilisé par le router pour les routes protégées (ginadapter.WithAuthenticator)
+func (m *AuthMiddleware) Authenticate(ctx httpgateway.Context) bool {
+	token, ok := strings.CutPrefix(ctx.Header("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		ctx.SetHeader("WWW-Authenticate", "Bearer")
+		ctx.Unauthorized("Authentification requise", "token manquant")
+		ctx.Abort()
+		return false
+	}
+
+	claims, err := m.tokens.Parse(token, tokengateway.Access)
+	if err != nil {
+		ctx.SetHeader("WWW-Authenticate", "Bearer error=\"invalid_token\"")
+		ctx.Unauthorized("Authentification requise", tokengateway.ErrInvalidToken.Error())
+		ctx.Abort()
+		return false
+	}
+
+	ctx.SetUser(httpgateway.AuthUser{
+		ID:          claims.UserID,
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: user.PermissionsOf(claims.Roles),
+	})
+	return true
+}
+
+// Require protège un handler: 401 sans token d'accès valide, sinon l'utilisateur authentifié
+// est enregistré dans le Context (ctx.User()) avant d'appeler h
+//
+//	r.Handle("GET", "/me", authMiddleware.Require(c.Me))
+//
+// Require est aussi un httpgateway.Middleware pour protéger un groupe de routes:
+//
+//	account := r.Group("/account", authMiddleware.Require)
+//
+// les options de route (httpgateway.Authenticated, Roles, Permissions) font la même vérification
+// et contrôlent en plus les rôles et permissions
+func (m *AuthMiddleware) Require(h func(httpgateway.Context)) func(httpgateway.Context) {
+	return func(ctx httpgateway.Context) {
+		if m.Authenticate(ctx) {
+			h(ctx)
+		}
+	}
+}
+`, moduleName)
+}
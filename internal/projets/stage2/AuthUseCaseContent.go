@@ -0,0 +1,151 @@
+package stage2
+
+import "fmt"
+
+func AuthUseCaseContent(moduleName string) string {
+	return fmt.Sprintf(`package authusecase
+
+import (
+	"context"
+	"errors"
+	"%[1]s/internal/application/gateway/passwordgateway"
+	"%[1]s/internal/application/gateway/tokengateway"
+	"%[1]s/internal/domain/domainerror"
+	"%[1]s/internal/domain/user"
+	"time"
+)
+
+const (
+	// AccessTtl durée de validité d'un token d'accès
+	AccessTtl = 15 * time.Minute
+	// RefreshTtl durée de validité d'un token de renouvellement
+	RefreshTtl = 7 * 24 * time.Hour
+)
+
+// ErrInvalidCredentials email ou mot de passe incorrect, sans préciser lequel
+var ErrInvalidCredentials = domainerror.Unauthorized("email ou mot de passe incorrect")
+
+// TokenPair tokens retournés à la connexion et au renouvellement
+type TokenPair struct {
+	AccessToken  string `+"`json:\"accessToken\"`"+`
+	RefreshToken string `+"`json:\"refreshToken\"`"+`
+	TokenType    string `+"`json:\"tokenType\"`"+`
+	ExpiresIn    int64  `+"`json:\"expiresIn\"`"+` // durée de validité du token d'accès en secondes
+}
+
+// AuthUseCase gère l'inscription, la connexion, le renouvellement des tokens et les rôles des utilisateurs
+type AuthUseCase struct {
+	repo      user.UserRepository
+	hasher    passwordgateway.Hasher
+	tokens    tokengateway.Tokens
+	dummyHash string // hash comparé à la connexion d'un email inconnu
+}
+
+// NewAuthUseCase crée une nouvelle instance du use case
+func NewAuthUseCase(repo user.UserRepository, hasher passwordgateway.Hasher, tokens tokengateway.Tokens) *AuthUseCase {
+	// hash fixe calculé par le hasher de l'api (même coût bcrypt que les mots de passe des utilisateurs)
+	dummyHash, _ := hasher.Hash("dummy-password")
+	return &AuthUseCase{
+		repo:      repo,
+		hasher:    hasher,
+		tokens:    tokens,
+		dummyHash: dummyHash,
+	}
+}
+
+// Register crée un utilisateur avec le rôle user, user.ErrEmailTaken si l'email est déjà utilisé.
+// L'email n'est pas vérifié: le rôle admin est donné hors de l'api (go run ./cmd/admin promote <email>)
+func (uc *AuthUseCase) Register(ctx context.Context, email, password string) (*user.User, error) {
+	hash, err := uc.hasher.Hash(password)
+	if err != nil {
+		return nil, err
+	}
+
+	userEntity := user.NewUser(email, hash)
+	if err := uc.repo.Create(ctx, userEntity); err != nil {
+		return nil, err
+	}
+
+	return userEntity, nil
+}
+
+// Login vérifie l'email et le mot de passe et retourne les tokens, ErrInvalidCredentials s'ils sont incorrects
+func (uc *AuthUseCase) Login(ctx context.Context, email, password string) (*TokenPair, error) {
+	userEntity, err := uc.repo.FindByEmail(ctx, email)
+	if errors.Is(err, user.ErrNotFound) {
+		// la comparaison avec un hash factice donne le même temps de réponse qu'un mot de passe incorrect,
+		// le temps de réponse ne révèle pas les emails inscrits
+		uc.hasher.Compare(uc.dummyHash, password)
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !uc.hasher.Compare(userEntity.PasswordHash, password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return uc.tokenPair(userEntity)
+}
+
+// Refresh retourne de nouveaux tokens à partir d'un token de renouvellement valide,
+// tokengateway.ErrInvalidToken si le token n'est pas valide ou si l'utilisateur n'existe plus
+func (uc *AuthUseCase) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := uc.tokens.Parse(refreshToken, tokengateway.Refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	userEntity, err := uc.repo.FindByID(ctx, claims.UserID)
+	if errors.Is(err, user.ErrNotFound) {
+		return nil, tokengateway.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.tokenPair(userEntity)
+}
+
+// GetUser récupère l'utilisateur authentifié
+func (uc *AuthUseCase) GetUser(ctx context.Context, id string) (*user.User, error) {
+	return uc.repo.FindByID(ctx, id)
+}
+
+// UpdateRoles remplace les rôles d'un utilisateur, user.ErrInvalidRole si un rôle n'existe pas.
+// Les nouveaux rôles sont pris en compte au prochain renouvellement des tokens
+func (uc *AuthUseCase) UpdateRoles(ctx context.Context, id string, roles []string) (*user.User, error) {
+	if err := user.ValidateRoles(roles); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdateRoles(ctx, id, roles); err != nil {
+		return nil, err
+	}
+
+	return uc.repo.FindByID(ctx, id)
+}
+
+func (uc *AuthUseCase) tokenPair(userEntity *user.User) (*TokenPair, error) {
+	claims := tokengateway.Claims{UserID: userEntity.ID, Email: userEntity.Email, Roles: userEntity.Roles}
+
+	claims.Type = tokengateway.Access
+	accessToken, err := uc.tokens.Generate(claims, AccessTtl)
+	if err != nil {
+		return nil, err
+	}
+
+	claims.Type = tokengateway.Refresh
+	refreshToken, err := uc.tokens.Generate(claims, RefreshTtl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(AccessTtl.Seconds()),
+	}, nil
+}
+`, moduleName)
+}
@@ -0,0 +1,60 @@
+package stage2
+
+func CacheGatewayContent() string {
+	return `package cachegateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrMiss clé absente ou expirée
+var ErrMiss = errors.New("clé absente du cache")
+
+// Cache représente le gateway pour le cache clé/valeur
+type Cache interface {
+	// Connect établit la connexion au cache
+	Connect(ctx context.Context) error
+
+	// Disconnect ferme la connexion au cache
+	Disconnect(ctx context.Context) error
+
+	// Ping vérifie la connexion au cache
+	Ping(ctx context.Context) error
+
+	// Get récupère la valeur d'une clé, ErrMiss si la clé est absente ou expirée
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set enregistre la valeur d'une clé, sans expiration si ttl vaut 0
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete supprime des clés, une clé absente est ignorée
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// GetJSON récupère la valeur JSON d'une clé, ErrMiss si la clé est absente ou expirée
+func GetJSON[T any](ctx context.Context, cache Cache, key string) (T, error) {
+	var value T
+	data, err := cache.Get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, fmt.Errorf("décodage de la clé %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// SetJSON enregistre la valeur d'une clé en JSON, sans expiration si ttl vaut 0
+func SetJSON(ctx context.Context, cache Cache, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encodage de la clé %s: %w", key, err)
+	}
+	return cache.Set(ctx, key, data, ttl)
+}
+`
+}
@@ -2,7 +2,9 @@ package stage2
 
 import "fmt"
 
-func ComposePreprodYamlContent(nameFolderProject string) string {
+func ComposePreprodYamlContent(nameFolderProject string, opts Options) string {
+	services := composeServicesContent(nameFolderProject, "preprod", opts)
+
 	return fmt.Sprintf(`name: %s-${APP_ENV}
 services:
   front:
@@ -21,9 +23,7 @@ services:
     networks:
       - traefik-nseven
       - %s
-    depends_on:
-      - api
-    restart: unless-stopped
+%s    restart: unless-stopped
 
   api:
     image: ghcr.io/nsevendev/%v/api:${IMAGE_TAG}
@@ -41,32 +41,14 @@ services:
     networks:
       - traefik-nseven
       - %s
-    depends_on:
-      - db
-    restart: unless-stopped
-
-  db:
-    image: mongo:7
-    container_name: %s_${APP_ENV}_db
-    restart: unless-stopped
-    volumes:
-      - %s_${APP_ENV}_db:/data/db
-      - ../docker/mongo-init:/docker-entrypoint-initdb.d
-    networks:
-      - traefik-nseven
-      - %s
-    environment:
-      - MONGO_INITDB_DATABASE=${DB_NAME}
-
+%s    restart: unless-stopped
+%s
 networks:
   traefik-nseven:
     external: true
   %s:
     driver: bridge
-
-volumes:
-  %s_preprod_db:
-`,
+%s`,
 		nameFolderProject, // name
 		nameFolderProject, // container_name front
 		nameFolderProject, // image front
@@ -76,6 +58,7 @@ volumes:
 		nameFolderProject, // router front tls.certresolver
 		nameFolderProject, // service front loadbalancer
 		nameFolderProject, // network front
+		services.Front,    // healthcheck et depends_on front
 
 		nameFolderProject, // container_name api
 		nameFolderProject, // image api
@@ -85,12 +68,10 @@ volumes:
 		nameFolderProject, // router api tls.certresolver
 		nameFolderProject, // service api loadbalancer
 		nameFolderProject, // network api
-
-		nameFolderProject, // container_name db
-		nameFolderProject, // volume db
-		nameFolderProject, // network db
+		services.Api,      // healthcheck, depends_on et volumes api
+		services.Services, // services db, redis
 
 		nameFolderProject, // network name
-		nameFolderProject, // volume name
+		services.Volumes,  // volumes
 	)
 }
@@ -0,0 +1,232 @@
+package stage2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// composeServices parties des fichiers compose qui dépendent des options du projet
+type composeServices struct {
+	Front    string // healthcheck et depends_on du service front
+	Api      string // healthcheck, depends_on et volumes du service api
+	Services string // services db, redis, mailpit, minio
+	Volumes  string // volumes nommés
+}
+
+// composeService service compose dont dépend l'api, démarrée quand son healthcheck passe
+type composeService struct {
+	name    string
+	content string
+	volume  string // suffixe du volume nommé, ex: db => <projet>_<env>_db, vide sans volume
+}
+
+// composeServicesContent parties compose d'un environnement (dev, preprod ou prod)
+func composeServicesContent(nameFolderProject, env string, opts Options) composeServices {
+	var services []composeService
+	var apiVolumes []string
+	if opts.Db == DbSqlite {
+		// pas de service db: la base est dans le dossier data de l'api,
+		// monté avec le dossier api en dev et dans un volume en preprod et prod
+		if env != "dev" {
+			apiVolumes = append(apiVolumes, composeVolume(nameFolderProject, env, "db")+":/app/data")
+		}
+	} else {
+		services = append(services, composeDbService(nameFolderProject, env, opts.Db))
+	}
+	if opts.Cache {
+		services = append(services, composeRedisService(nameFolderProject, env))
+	}
+	if opts.Mailer && env == "dev" {
+		// en preprod et prod les mails partent par le serveur SMTP du .env de l'api
+		services = append(services, composeMailpitService(nameFolderProject))
+	}
+	if opts.Storage && env == "dev" {
+		// en preprod et prod les fichiers vont dans le bucket R2 du .env de l'api
+		services = append(services, composeMinioService(nameFolderProject))
+	}
+
+	var parts composeServices
+	var volumes []string
+	if len(apiVolumes) > 0 {
+		volumes = append(volumes, "db")
+	}
+
+	// le front répond dès que le serveur node écoute, en preprod et prod il attend que l'api soit prête
+	parts.Front = composeHealthcheck(`["CMD", "node", "-e", "fetch('http://127.0.0.1:'+(process.env.PORT||3000)).then(r=>process.exit(r.status<500?0:1),()=>process.exit(1))"]`, "30s")
+	if env != "dev" {
+		parts.Front += "    depends_on:\n      api:\n        condition: service_healthy\n"
+	}
+
+	// l'api est prête quand /health/ready répond: base, cache, mail et stockage joignables,
+	// en dev le démarrage inclut la compilation par air
+	apiStartPeriod := "30s"
+	if env == "dev" {
+		apiStartPeriod = "120s"
+	}
+	parts.Api = composeHealthcheck(`["CMD-SHELL", "curl -fsS http://127.0.0.1:$${PORT}/health/ready > /dev/null || exit 1"]`, apiStartPeriod)
+	if len(services) > 0 {
+		parts.Api += "    depends_on:\n"
+	}
+	for _, service := range services {
+		parts.Api += fmt.Sprintf("      %s:\n        condition: service_healthy\n", service.name)
+		parts.Services += service.content
+		if service.volume != "" {
+			volumes = append(volumes, service.volume)
+		}
+	}
+	if len(apiVolumes) > 0 {
+		parts.Api += "    volumes:\n      - " + strings.Join(apiVolumes, "\n      - ") + "\n"
+	}
+	if len(volumes) > 0 {
+		parts.Volumes = "\nvolumes:\n"
+		for _, volume := range volumes {
+			parts.Volumes += fmt.Sprintf("  %s_%s_%s:\n", nameFolderProject, env, volume)
+		}
+	}
+	return parts
+}
+
+// composeHealthcheck healthcheck d'un service, test au format compose, ex: ["CMD", "redis-cli", "ping"]
+func composeHealthcheck(test, startPeriod string) string {
+	return fmt.Sprintf(`    healthcheck:
+      test: %s
+      interval: 10s
+      timeout: 5s
+      retries: 5
+      start_period: %s
+`, test, startPeriod)
+}
+
+// composeVolume volume nommé d'un service, <projet>_dev_<suffixe> en dev, <projet>_${APP_ENV}_<suffixe> sinon
+func composeVolume(nameFolderProject, env, suffix string) string {
+	if env == "dev" {
+		return fmt.Sprintf("%s_dev_%s", nameFolderProject, suffix)
+	}
+	return fmt.Sprintf("%s_${APP_ENV}_%s", nameFolderProject, suffix)
+}
+
+// composeDbService service db mongo ou postgres, avec un script de création des bases par environnement
+func composeDbService(nameFolderProject, env string, db Db) composeService {
+	image, data, initDir, port := "mongo:7", "/data/db", "mongo-init", "27017"
+	test := `["CMD", "mongosh", "--quiet", "--eval", "db.adminCommand('ping')"]`
+	if db == DbPostgres {
+		image, data, initDir, port = "postgres:16", "/var/lib/postgresql/data", "postgres-init", "5432"
+		test = `["CMD-SHELL", "pg_isready -U $${POSTGRES_USER}"]`
+	}
+
+	service := fmt.Sprintf(`
+  db:
+    image: %s
+    container_name: %s_${APP_ENV}_db
+    restart: unless-stopped
+`, image, nameFolderProject)
+	if db == DbPostgres {
+		// POSTGRES_USER et POSTGRES_PASSWORD du .env de l'api
+		service += `    env_file:
+      - ../api/.env
+`
+	}
+	service += fmt.Sprintf(`    volumes:
+      - %s:%s
+      - ../docker/%s:/docker-entrypoint-initdb.d
+`, composeVolume(nameFolderProject, env, "db"), data, initDir)
+	if env == "dev" {
+		service += fmt.Sprintf(`    ports:
+      - "${DB_PORT_EX:-%s}:%s"
+`, port, port)
+	}
+	service += composeHealthcheck(test, "30s")
+	service += fmt.Sprintf(`    networks:
+      - traefik-nseven
+      - %s
+`, nameFolderProject)
+	if env != "dev" && db == DbMongo {
+		service += `    environment:
+      - MONGO_INITDB_DATABASE=${DB_NAME}
+`
+	}
+
+	return composeService{name: "db", content: service, volume: "db"}
+}
+
+// composeRedisService service redis du cache, persistance AOF dans un volume nommé
+func composeRedisService(nameFolderProject, env string) composeService {
+	return composeService{
+		name: "redis",
+		content: fmt.Sprintf(`
+  redis:
+    image: redis:7-alpine
+    container_name: %s_${APP_ENV}_redis
+    restart: unless-stopped
+    command: ["redis-server", "--appendonly", "yes"]
+    volumes:
+      - %s:/data
+%s    networks:
+      - %s
+`, nameFolderProject, composeVolume(nameFolderProject, env, "redis"), composeHealthcheck(`["CMD", "redis-cli", "ping"]`, "10s"), nameFolderProject),
+		volume: "redis",
+	}
+}
+
+// composeMailpitService service mailpit qui capture les mails en dev, interface web routée par traefik
+func composeMailpitService(nameFolderProject string) composeService {
+	return composeService{
+		name: "mailpit",
+		content: fmt.Sprintf(`
+  mailpit:
+    image: axllent/mailpit:v1.27
+    container_name: %[1]s_${APP_ENV}_mailpit
+    restart: unless-stopped
+    labels:
+      - "traefik.enable=true"
+      - "traefik.docker.network=traefik-nseven"
+      - "traefik.http.routers.%[1]s-mailpit.rule=${HOST_TRAEFIK_MAILPIT}"
+      - "traefik.http.routers.%[1]s-mailpit.entrypoints=websecure"
+      - "traefik.http.routers.%[1]s-mailpit.tls=true"
+      - "traefik.http.routers.%[1]s-mailpit.tls.certresolver=default"
+      - "traefik.http.services.%[1]s-mailpit.loadbalancer.server.port=8025"
+      - "traefik.http.services.%[1]s-mailpit.loadbalancer.server.scheme=http"
+%[2]s    networks:
+      - traefik-nseven
+      - %[1]s
+`, nameFolderProject, composeHealthcheck(`["CMD", "/mailpit", "readyz"]`, "10s")),
+	}
+}
+
+// composeMinioService service minio (api S3) du stockage en dev, api S3 et console web routées par traefik
+func composeMinioService(nameFolderProject string) composeService {
+	return composeService{
+		name: "minio",
+		content: fmt.Sprintf(`
+  minio:
+    image: minio/minio:RELEASE.2025-04-22T22-12-26Z
+    container_name: %[1]s_${APP_ENV}_minio
+    restart: unless-stopped
+    command: ["server", "/data", "--console-address", ":9001"]
+    environment:
+      - MINIO_ROOT_USER=minioadmin
+      - MINIO_ROOT_PASSWORD=minioadmin
+    volumes:
+      - %[2]s:/data
+    labels:
+      - "traefik.enable=true"
+      - "traefik.docker.network=traefik-nseven"
+      - "traefik.http.routers.%[1]s-minio.rule=${HOST_TRAEFIK_MINIO}"
+      - "traefik.http.routers.%[1]s-minio.entrypoints=websecure"
+      - "traefik.http.routers.%[1]s-minio.tls=true"
+      - "traefik.http.routers.%[1]s-minio.tls.certresolver=default"
+      - "traefik.http.routers.%[1]s-minio.service=%[1]s-minio"
+      - "traefik.http.services.%[1]s-minio.loadbalancer.server.port=9000"
+      - "traefik.http.routers.%[1]s-minio-console.rule=${HOST_TRAEFIK_MINIO_CONSOLE}"
+      - "traefik.http.routers.%[1]s-minio-console.entrypoints=websecure"
+      - "traefik.http.routers.%[1]s-minio-console.tls=true"
+      - "traefik.http.routers.%[1]s-minio-console.tls.certresolver=default"
+      - "traefik.http.routers.%[1]s-minio-console.service=%[1]s-minio-console"
+      - "traefik.http.services.%[1]s-minio-console.loadbalancer.server.port=9001"
+%[3]s    networks:
+      - traefik-nseven
+      - %[1]s
+`, nameFolderProject, composeVolume(nameFolderProject, "dev", "minio"), composeHealthcheck(`["CMD", "mc", "ready", "local"]`, "10s")),
+		volume: "minio",
+	}
+}
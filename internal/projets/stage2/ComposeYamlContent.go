@@ -2,7 +2,9 @@ package stage2
 
 import "fmt"
 
-func ComposeYamlContent(nameFolderProject string) string {
+func ComposeYamlContent(nameFolderProject string, opts Options) string {
+	services := composeServicesContent(nameFolderProject, "dev", opts)
+
 	return fmt.Sprintf(`name: %s-${APP_ENV}
 services:
   front:
@@ -28,7 +30,7 @@ services:
     networks:
       - traefik-nseven
       - %s
-
+%s
   api:
     build:
       target: ${APP_ENV}
@@ -54,31 +56,13 @@ services:
     networks:
       - traefik-nseven
       - %s
-    depends_on:
-      - db
-
-  db:
-    image: mongo:7
-    container_name: %s_${APP_ENV}_db
-    restart: unless-stopped
-    volumes:
-      - %s_dev_db:/data/db
-      - ../docker/mongo-init:/docker-entrypoint-initdb.d
-    ports:
-      - "${DB_PORT_EX:-27017}:27017"
-    networks:
-      - traefik-nseven
-      - %s
-
+%s%s
 networks:
   %s:
     driver: bridge
   traefik-nseven:
     external: true
-
-volumes:
-  %s_dev_db:
-`,
+%s`,
 		nameFolderProject, // name project
 		nameFolderProject, // container_name front
 		nameFolderProject, // image front
@@ -89,6 +73,7 @@ volumes:
 		nameFolderProject, // service front loadbalancer
 		nameFolderProject, // service front loadbalancer scheme
 		nameFolderProject, // network front
+		services.Front,    // healthcheck front
 
 		nameFolderProject, // container_name api
 		nameFolderProject, // image api
@@ -99,12 +84,10 @@ volumes:
 		nameFolderProject, // service api loadbalancer
 		nameFolderProject, // service api loadbalancer scheme
 		nameFolderProject, // network api
-
-		nameFolderProject, // container_name db
-		nameFolderProject, // volume db
-		nameFolderProject, // network db
+		services.Api,      // healthcheck, depends_on et volumes api
+		services.Services, // services db, redis
 
 		nameFolderProject, // network name
-		nameFolderProject, // volume db
+		services.Volumes,  // volumes
 	)
 }
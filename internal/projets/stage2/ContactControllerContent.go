@@ -0,0 +1,71 @@
+package stage2
+
+import "fmt"
+
+func ContactControllerContent(moduleName string) string {
+	return fmt.Sprintf(`package contactcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+	"%s/internal/application/usecase/contactusecase"
+)
+
+type ContactController struct {
+	useCase   *contactusecase.ContactUseCase
+	prefixUrl string
+}
+
+// New crée une nouvelle instance du controller Contact
+func New(useCase *contactusecase.ContactUseCase) *ContactController {
+	return &ContactController{
+		useCase:   useCase,
+		prefixUrl: "/contact",
+	}
+}
+
+// RegisterRoutes enregistre les routes du controller
+func (c *ContactController) RegisterRoutes(r httpgateway.Router) {
+	r.Handle("POST", c.prefixUrl, c.SendContact)
+}
+`, moduleName, moduleName)
+}
+
+func ContactSendContent(moduleName string) string {
+	return fmt.Sprintf(`package contactcontroller
+
+import "%s/internal/application/gateway/httpgateway"
+
+// SendContactRequest message du formulaire de contact
+type SendContactRequest struct {
+	Name    string `+"`json:\"name\" binding:\"required,max=100\"`"+`
+	Email   string `+"`json:\"email\" binding:\"required,email\"`"+`
+	Message string `+"`json:\"message\" binding:\"required,max=5000\"`"+`
+}
+
+// SendContact envoie un message du formulaire de contact par mail
+// @Summary Envoyer un message de contact
+// @Description Envoie le message d'un visiteur par mail à l'adresse de contact
+// @Tags Contact
+// @Accept json
+// @Produce json
+// @Param body body SendContactRequest true "Message à envoyer"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /contact [post]
+func (c *ContactController) SendContact(ctx httpgateway.Context) {
+	var req SendContactRequest
+	if !ctx.BindJSON(&req) {
+		return
+	}
+
+	if err := c.useCase.SendContact(ctx.Request().Context(), req.Name, req.Email, req.Message); err != nil {
+		ctx.InternalServerError("Erreur lors de l'envoi du message", err.Error())
+		return
+	}
+
+	ctx.Success("Message envoyé", nil)
+}
+`, moduleName)
+}
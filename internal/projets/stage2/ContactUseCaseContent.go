@@ -0,0 +1,43 @@
+package stage2
+
+import "fmt"
+
+func ContactUseCaseContent(moduleName string) string {
+	return fmt.Sprintf(`package contactusecase
+
+import (
+	"context"
+	"fmt"
+	"%s/internal/application/gateway/mailergateway"
+)
+
+// ContactUseCase envoie les messages du formulaire de contact par mail
+type ContactUseCase struct {
+	mailer    mailergateway.Mailer
+	recipient string
+}
+
+// NewContactUseCase crée une nouvelle instance du use case, recipient reçoit les messages (MAIL_CONTACT)
+func NewContactUseCase(mailer mailergateway.Mailer, recipient string) *ContactUseCase {
+	return &ContactUseCase{
+		mailer:    mailer,
+		recipient: recipient,
+	}
+}
+
+// SendContact envoie le message d'un visiteur avec le template contact, la réponse au mail va au visiteur
+func (uc *ContactUseCase) SendContact(ctx context.Context, name, email, message string) error {
+	return uc.mailer.Send(ctx, mailergateway.Message{
+		To:       []string{uc.recipient},
+		ReplyTo:  email,
+		Subject:  fmt.Sprintf("Nouveau message de %%s", name),
+		Template: "contact",
+		Data: map[string]string{
+			"Name":    name,
+			"Email":   email,
+			"Message": message,
+		},
+	})
+}
+`, moduleName)
+}
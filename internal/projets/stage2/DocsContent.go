@@ -0,0 +1,62 @@
+package stage2
+
+import "fmt"
+
+// docsSwagger swagger initial (sans routes) reprenant les annotations de MainGoContent, remplacé par swag init
+const docsSwagger = `{
+    "schemes": %s,
+    "swagger": "2.0",
+    "info": {
+        "description": %s,
+        "title": %s,
+        "contact": {},
+        "version": %s
+    },
+    "host": %s,
+    "basePath": %s,
+    "paths": {},
+    "securityDefinitions": {
+        "BearerAuth": {
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// DocsGoContent package docs initial (docs/docs.go) au format de swag init, pour que l'api compile avant la première génération
+func DocsGoContent() string {
+	template := fmt.Sprintf(docsSwagger, "{{ marshal .Schemes }}", `"{{escape .Description}}"`, `"{{.Title}}"`, `"{{.Version}}"`, `"{{.Host}}"`, `"{{.BasePath}}"`)
+	return fmt.Sprintf(`// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `+"`%s`"+`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "",
+	BasePath:         "/api/v1",
+	Schemes:          []string{"https"},
+	Title:            "nseven api",
+	Description:      "API service nseven api",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
+`, template)
+}
+
+// DocsSwaggerJsonContent swagger initial (docs/swagger.json)
+func DocsSwaggerJsonContent() string {
+	return fmt.Sprintf(docsSwagger, `[
+        "https"
+    ]`, `"API service nseven api"`, `"nseven api"`, `"1.0"`, `""`, `"/api/v1"`) + "\n"
+}
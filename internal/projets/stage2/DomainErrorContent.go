@@ -0,0 +1,63 @@
+package stage2
+
+func DomainErrorContent() string {
+	return `package domainerror
+
+import "errors"
+
+// Kind catégorie d'une erreur métier, traduite en réponse http par ctx.Error
+type Kind string
+
+const (
+	// NotFoundKind ressource inexistante (404)
+	NotFoundKind Kind = "not_found"
+	// ConflictKind ressource déjà existante, ex: valeur unique déjà utilisée (409)
+	ConflictKind Kind = "conflict"
+	// ValidationKind donnée refusée par une règle métier (422)
+	ValidationKind Kind = "validation"
+	// UnauthorizedKind authentification absente ou refusée (401)
+	UnauthorizedKind Kind = "unauthorized"
+)
+
+// Error erreur métier typée, retournée par les repositories et les use cases telle quelle
+// ou enveloppée (fmt.Errorf("%w: ...", err)), déclarée en variable pour errors.Is:
+//
+//	var ErrNotFound = domainerror.NotFound("produit non trouvé")
+type Error struct {
+	Kind    Kind
+	Message string
+	Field   string // champ concerné par une erreur de validation
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NotFound erreur d'une ressource inexistante
+func NotFound(message string) *Error {
+	return &Error{Kind: NotFoundKind, Message: message}
+}
+
+// Conflict erreur d'une ressource déjà existante
+func Conflict(message string) *Error {
+	return &Error{Kind: ConflictKind, Message: message}
+}
+
+// Validation erreur d'une donnée refusée, field vide si elle ne concerne pas un champ
+func Validation(field, message string) *Error {
+	return &Error{Kind: ValidationKind, Message: message, Field: field}
+}
+
+// Unauthorized erreur d'authentification
+func Unauthorized(message string) *Error {
+	return &Error{Kind: UnauthorizedKind, Message: message}
+}
+
+// As erreur métier contenue dans err, false si err n'en contient pas
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	ok := errors.As(err, &domainErr)
+	return domainErr, ok
+}
+`
+}
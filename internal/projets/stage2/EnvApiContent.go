@@ -2,39 +2,105 @@ package stage2
 
 import "fmt"
 
-func EnvApiContent(nameFolderProject string, hostApi string, hostFront string) string {
+func EnvApiContent(nameFolderProject string, hostApi string, hostFront string, opts Options) string {
 	return fmt.Sprintf(`APP_ENV=dev
 # connexion db en mode dev
-DB_NAME=%v_dev # change me
-DB_URI=mongodb://db:27017
-# pour les logs
+%v# pour les logs
 HOST_TRAEFIK_API=Host(`+"`%v`"+`) # change me
 # pour start server
 PORT=3000
-# info pour port db
-DB_PORT_EX=27018
-# clef secrete jwt
+# erreurs au format RFC 7807 (application/problem+json) si true
+PROBLEM_JSON=false
+# migrations en attente appliquées au démarrage si différent de false (sinon make migrate-up)
+MIGRATE_ON_START=true
+%v# clef secrete jwt
 JWT_SECRET_KEY=supersecretkey
 
-# S3 cloudflare R2
+%v
+# pour redis
+REDIS_ADDR=redis:6379
+REDIS_PASSWORD=
+
+%v
+# host cors
+CORS_DEV_APP=https://%v
+CORS_PREPROD_APP=https://%v # change me in preprod
+CORS_PROD_APP=https://%v # change me in prod
+`, envApiDbContent(nameFolderProject, opts.Db), hostApi, envApiDbPortContent(opts.Db), envApiStorageContent(nameFolderProject, hostFront, opts), envApiMailContent(opts), hostFront, hostFront, hostFront)
+}
+
+// envApiStorageContent bucket S3 cloudflare R2, minio du compose dev avec --storage
+func envApiStorageContent(nameFolderProject, hostFront string, opts Options) string {
+	if opts.Storage {
+		return fmt.Sprintf(`# S3 cloudflare R2 (minio en dev)
+# R2_ENDPOINT vide => <R2_ACCOUNT_ID>.r2.cloudflarestorage.com, R2_USE_SSL=true et R2_PUBLIC_ENDPOINT vide en preprod et prod
+R2_ACCOUNT_ID=
+R2_ACCESS_KEY_ID=minioadmin
+R2_SECRET_ACCESS_KEY=minioadmin
+R2_BUCKET_NAME=%v
+R2_ENDPOINT=minio:9000
+R2_USE_SSL=false
+# host des urls présignées, joignable par le navigateur
+R2_PUBLIC_ENDPOINT=minio.%v
+`, nameFolderProject, hostFront)
+	}
+	return `# S3 cloudflare R2
 R2_ACCOUNT_ID=
 R2_ACCESS_KEY_ID=
 R2_SECRET_ACCESS_KEY=
 R2_BUCKET_NAME=
+`
+}
 
-# pour redis
-REDIS_ADDR=redis:6379
-
-# mailer
+// envApiMailContent serveur SMTP, mailpit du compose dev avec --mailer
+func envApiMailContent(opts Options) string {
+	if opts.Mailer {
+		return `# mailer (mailpit en dev, serveur SMTP en preprod et prod)
+MAIL_HOST=mailpit
+MAIL_PORT=1025
+MAIL_USER=
+MAIL_PASS=
+MAIL_FROM=john@example.com
+# destinataire des messages du formulaire de contact
+MAIL_CONTACT=contact@example.com
+`
+	}
+	return `# mailer
 MAIL_HOST=sandbox.smtp.mailtrap.io
 MAIL_PORT=587
 MAIL_USER=79a16633028c7e
 MAIL_PASS=a39213807af5f5
 MAIL_FROM=john@example.com
+`
+}
 
-# host cors
-CORS_DEV_APP=https://%v
-CORS_PREPROD_APP=https://%v # change me in preprod
-CORS_PROD_APP=https://%v # change me in prod
-`, nameFolderProject, hostApi, hostFront, hostFront, hostFront)
+// envApiDbContent connexion à la base de données de l'api
+func envApiDbContent(nameFolderProject string, db Db) string {
+	switch db {
+	case DbPostgres:
+		return fmt.Sprintf(`DB_NAME=%[1]v_dev # change me
+DB_URI=postgres://%[1]v:%[1]v@db:5432/%[1]v_dev?sslmode=disable # change me
+# compte postgres créé au premier lancement du conteneur db
+POSTGRES_USER=%[1]v
+POSTGRES_PASSWORD=%[1]v # change me
+`, nameFolderProject)
+	case DbSqlite:
+		return fmt.Sprintf(`DB_NAME=%[1]v_dev # change me
+DB_URI=file:data/%[1]v_dev.db?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)
+`, nameFolderProject)
+	}
+	return fmt.Sprintf(`DB_NAME=%v_dev # change me
+DB_URI=mongodb://db:27017
+`, nameFolderProject)
+}
+
+// envApiDbPortContent port externe de la base de données, sqlite n'a pas de conteneur db
+func envApiDbPortContent(db Db) string {
+	switch db {
+	case DbPostgres:
+		return "# info pour port db\nDB_PORT_EX=5433\n"
+	case DbSqlite:
+		return ""
+	}
+	return "# info pour port db\nDB_PORT_EX=27018\n"
 }
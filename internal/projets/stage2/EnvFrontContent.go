@@ -2,8 +2,10 @@ package stage2
 
 import "fmt"
 
-func EnvFrontContent() string {
+func EnvFrontContent(hostTraefikApi string) string {
 	return fmt.Sprintf(`# modifier selon environement, dev, preprod, prod)
 APP_ENV=dev
-`)
+# url de l'api utilisée par le client généré (starter client generate)
+PUBLIC_API_URL=https://%v/api/v1
+`, hostTraefikApi)
 }
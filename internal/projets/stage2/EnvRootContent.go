@@ -2,14 +2,44 @@ package stage2
 
 import "fmt"
 
-func EnvRootContent(hostTraefikFront, hostTraefikApi string) string {
+func EnvRootContent(hostTraefikFront, hostTraefikApi string, opts Options) string {
 	return fmt.Sprintf(`# dev, preprod, prod à changer en fonction de l'environnement
 APP_ENV=dev
 # pour traefik dans le compose (à adapter en fonction de votre configuration locale)
 HOST_TRAEFIK_FRONT=Host(`+"`%v`"+`)
 HOST_TRAEFIK_API=Host(`+"`%v`"+`)
 PORT=3000
-# access externe database # a supprimer en prod ou preprod
-DB_PORT_EX=27017
-`, hostTraefikFront, hostTraefikApi)
+%v%v%v`, hostTraefikFront, hostTraefikApi, envRootDbPortContent(opts.Db), envRootMailpitContent(hostTraefikFront, opts), envRootMinioContent(hostTraefikFront, opts))
+}
+
+// envRootMinioContent hosts traefik de l'api S3 et de la console de minio (dev)
+func envRootMinioContent(hostTraefikFront string, opts Options) string {
+	if !opts.Storage {
+		return ""
+	}
+	return fmt.Sprintf(`# api S3 et console de minio en dev (à adapter en fonction de votre configuration locale)
+HOST_TRAEFIK_MINIO=Host(`+"`minio.%v`"+`)
+HOST_TRAEFIK_MINIO_CONSOLE=Host(`+"`minio-console.%v`"+`)
+`, hostTraefikFront, hostTraefikFront)
+}
+
+// envRootMailpitContent host traefik de l'interface web de mailpit (dev)
+func envRootMailpitContent(hostTraefikFront string, opts Options) string {
+	if !opts.Mailer {
+		return ""
+	}
+	return fmt.Sprintf(`# interface web des mails capturés en dev (à adapter en fonction de votre configuration locale)
+HOST_TRAEFIK_MAILPIT=Host(`+"`mailpit.%v`"+`)
+`, hostTraefikFront)
+}
+
+// envRootDbPortContent port externe de la base de données pour le compose dev
+func envRootDbPortContent(db Db) string {
+	switch db {
+	case DbPostgres:
+		return "# access externe database # a supprimer en prod ou preprod\nDB_PORT_EX=5432\n"
+	case DbSqlite:
+		return ""
+	}
+	return "# access externe database # a supprimer en prod ou preprod\nDB_PORT_EX=27017\n"
 }
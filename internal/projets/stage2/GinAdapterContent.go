@@ -6,30 +6,65 @@ func GinAdapterContent(moduleName string) string {
 	return fmt.Sprintf(`package ginadapter
 
 import (
+	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/nsevenpack/ginresponse"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"%s/internal/application/gateway/httpgateway"
 	"%s/internal/application/gateway/loggateway"
+	"%s/internal/domain/domainerror"
+	"reflect"
+	"slices"
+	"strings"
 )
 
-const defaultPrefix = "/api/v1"
+// userKey clé de l'utilisateur authentifié dans le contexte gin
+const userKey = "httpgateway.user"
 
 type ginRouter struct {
-	r      *gin.Engine
-	prefix string
-	logger loggateway.Logger
+	r             *gin.Engine
+	prefix        string
+	middlewares   []httpgateway.Middleware
+	logger        loggateway.Logger
+	authenticator httpgateway.Authenticator
+	problemJSON   bool
 }
 
 type ginContext struct {
-	c      *gin.Context
-	logger loggateway.Logger
+	c           *gin.Context
+	logger      loggateway.Logger
+	problemJSON bool
 }
 
-func New(r *gin.Engine, logger loggateway.Logger) httpgateway.Router {
+// Option option du router
+type Option func(*ginRouter)
+
+// WithAuthenticator authentification des routes protégées (httpgateway.Authenticated, Roles, Permissions)
+func WithAuthenticator(authenticator httpgateway.Authenticator) Option {
+	return func(g *ginRouter) {
+		g.authenticator = authenticator
+	}
+}
+
+// WithProblemJSON réponses d'erreur au format RFC 7807 (application/problem+json) si enabled
+func WithProblemJSON(enabled bool) Option {
+	return func(g *ginRouter) {
+		g.problemJSON = enabled
+	}
+}
+
+func New(r *gin.Engine, logger loggateway.Logger, options ...Option) httpgateway.Router {
 	ginresponse.SetFormatter(&ginresponse.JsonFormatter{})
-	return &ginRouter{r: r, prefix: defaultPrefix, logger: logger}
+	g := &ginRouter{r: r, logger: logger}
+	for _, option := range options {
+		option(g)
+	}
+	return g
 }
 
 // wrapHandler wraps gin.Context with logging metadata
@@ -44,23 +79,75 @@ func (g *ginRouter) wrapHandler(c *gin.Context, h func(httpgateway.Context)) {
 		"path", path,
 		"ip", c.ClientIP(),
 	)
-	h(&ginContext{c: c, logger: reqLog})
+	h(&ginContext{c: c, logger: reqLog, problemJSON: g.problemJSON})
 }
 
-func (g *ginRouter) Handle(method, path string, h func(httpgateway.Context)) {
+func (g *ginRouter) Use(middlewares ...httpgateway.Middleware) {
+	g.middlewares = append(g.middlewares, middlewares...)
+}
+
+func (g *ginRouter) Group(prefix string, middlewares ...httpgateway.Middleware) httpgateway.Router {
+	return &ginRouter{
+		r:             g.r,
+		prefix:        g.prefix + prefix,
+		middlewares:   slices.Concat(g.middlewares, middlewares),
+		logger:        g.logger,
+		authenticator: g.authenticator,
+		problemJSON:   g.problemJSON,
+	}
+}
+
+// chain applique les middlewares du router à h, dans leur ordre d'ajout
+func (g *ginRouter) chain(h func(httpgateway.Context)) func(httpgateway.Context) {
+	for i := len(g.middlewares) - 1; i >= 0; i-- {
+		h = g.middlewares[i](h)
+	}
+	return h
+}
+
+func (g *ginRouter) Handle(method, path string, h func(httpgateway.Context), options ...httpgateway.RouteOption) {
+	if access := httpgateway.NewAccess(options...); access.Authenticated {
+		h = g.guard(access, h)
+	}
+	h = g.chain(h)
 	path = g.prefix + path
 	g.r.Handle(method, path, func(c *gin.Context) {
 		g.wrapHandler(c, h)
 	})
 }
 
+// guard vérifie l'authentification (401) puis les rôles et permissions (403) avant d'appeler h
+func (g *ginRouter) guard(access httpgateway.Access, h func(httpgateway.Context)) func(httpgateway.Context) {
+	return func(ctx httpgateway.Context) {
+		if g.authenticator == nil {
+			ctx.Unauthorized("Authentification requise", "aucune authentification configurée")
+			ctx.Abort()
+			return
+		}
+		if !g.authenticator(ctx) {
+			return
+		}
+
+		user, ok := ctx.User()
+		if !ok || !access.Allowed(user) {
+			ctx.Forbidden("Accès refusé", "rôle ou permission insuffisant")
+			ctx.Abort()
+			return
+		}
+
+		h(ctx)
+	}
+}
+
 func (g *ginRouter) NoRoute(h func(httpgateway.Context)) {
+	h = g.chain(h)
 	g.r.NoRoute(func(c *gin.Context) {
 		g.wrapHandler(c, h)
 	})
 }
 
 func (g *ginRouter) NoMethod(h func(httpgateway.Context)) {
+	h = g.chain(h)
 	g.r.NoMethod(func(c *gin.Context) {
 		g.wrapHandler(c, h)
 	})
@@ -111,6 +198,133 @@ func (g *ginContext) SaveUploadedFile(f *multipart.FileHeader, dst string) error
 	return g.c.SaveUploadedFile(f, dst)
 }
 
+/* BINDING */
+
+// validate valide les requêtes liées par BindJSON, BindQuery et BindURI selon leurs tags binding,
+// les champs en erreur sont nommés par leur tag json, form ou uri
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "uri"} {
+			name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return ""
+	})
+	return v
+}
+
+func (g *ginContext) BindJSON(obj any) bool {
+	if err := json.NewDecoder(g.c.Request.Body).Decode(obj); err != nil {
+		g.BadRequest("Corps de la requête invalide", []httpgateway.ErrorResponse{decodeError(err)})
+		return false
+	}
+	return g.validateStruct(obj)
+}
+
+func (g *ginContext) BindQuery(obj any) bool {
+	if err := binding.MapFormWithTag(obj, g.c.Request.URL.Query(), "form"); err != nil {
+		g.BadRequest("Paramètres query invalides", []httpgateway.ErrorResponse{{Message: "paramètre invalide", Type: "query", Detail: err.Error()}})
+		return false
+	}
+	return g.validateStruct(obj)
+}
+
+func (g *ginContext) BindURI(obj any) bool {
+	params := make(map[string][]string, len(g.c.Params))
+	for _, p := range g.c.Params {
+		params[p.Key] = []string{p.Value}
+	}
+	if err := binding.MapFormWithTag(obj, params, "uri"); err != nil {
+		g.BadRequest("Paramètres de chemin invalides", []httpgateway.ErrorResponse{{Message: "paramètre invalide", Type: "uri", Detail: err.Error()}})
+		return false
+	}
+	return g.validateStruct(obj)
+}
+
+// validateStruct valide un struct selon ses tags binding, répond 422 avec une ErrorResponse par champ invalide
+func (g *ginContext) validateStruct(obj any) bool {
+	if reflect.Indirect(reflect.ValueOf(obj)).Kind() != reflect.Struct {
+		return true
+	}
+
+	err := validate.Struct(obj)
+	var fieldErrors validator.ValidationErrors
+	if errors.As(err, &fieldErrors) {
+		g.UnprocessableEntity("Requête invalide", validationErrors(fieldErrors))
+		return false
+	}
+	if err != nil {
+		g.InternalServerError("Validation de la requête impossible", err.Error())
+		return false
+	}
+	return true
+}
+
+// decodeError erreur de décodage JSON, avec le champ si une valeur n'a pas le type attendu
+func decodeError(err error) httpgateway.ErrorResponse {
+	var typeError *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return httpgateway.ErrorResponse{Message: "corps de la requête absent", Type: "decode", Detail: err.Error()}
+	case errors.As(err, &typeError):
+		return httpgateway.ErrorResponse{Message: "type invalide", Type: "decode", Field: typeError.Field, Detail: "type attendu: " + typeError.Type.String()}
+	}
+	return httpgateway.ErrorResponse{Message: "JSON invalide", Type: "decode", Detail: err.Error()}
+}
+
+// validationErrors une ErrorResponse par règle binding non respectée, Field est le chemin du champ (ex: "address.city")
+func validationErrors(fieldErrors validator.ValidationErrors) []httpgateway.ErrorResponse {
+	responses := make([]httpgateway.ErrorResponse, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		message := "règle " + rule + " non respectée"
+		switch fe.Tag() {
+		case "required":
+			message = "champ obligatoire"
+		case "email":
+			message = "email invalide"
+		case "oneof":
+			message = "valeur attendue parmi: " + fe.Param()
+		}
+
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		responses[i] = httpgateway.ErrorResponse{
+			Message: message,
+			Type:    "validation",
+			Field:   field,
+			Detail:  rule,
+		}
+	}
+	return responses
+}
+
+/* AUTHENTICATED USER */
+
+func (g *ginContext) SetUser(user httpgateway.AuthUser) {
+	g.c.Set(userKey, user)
+}
+
+func (g *ginContext) User() (httpgateway.AuthUser, bool) {
+	value, ok := g.c.Get(userKey)
+	if !ok {
+		return httpgateway.AuthUser{}, false
+	}
+	user, ok := value.(httpgateway.AuthUser)
+	return user, ok
+}
+
 /* SUCCESS REPONSE */
 
 // Success responses (2xx)
@@ -131,48 +345,129 @@ func (g *ginContext) NoContent(message string) {
 
 // BadRequest Client error responses (4xx)
 func (g *ginContext) BadRequest(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.BadRequest)
+	g.respondError(http.StatusBadRequest, message, err, ginresponse.BadRequest)
 }
 
 func (g *ginContext) Unauthorized(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.Unauthorized)
+	g.respondError(http.StatusUnauthorized, message, err, ginresponse.Unauthorized)
 }
 
 func (g *ginContext) Forbidden(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.Forbidden)
+	g.respondError(http.StatusForbidden, message, err, ginresponse.Forbidden)
 }
 
 func (g *ginContext) NotFound(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.NotFound)
+	g.respondError(http.StatusNotFound, message, err, ginresponse.NotFound)
 }
 
 func (g *ginContext) UnprocessableEntity(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.UnprocessableEntity)
+	g.respondError(http.StatusUnprocessableEntity, message, err, ginresponse.UnprocessableEntity)
 }
 
 func (g *ginContext) Conflict(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.Conflict)
+	g.respondError(http.StatusConflict, message, err, ginresponse.Conflict)
 }
 
 func (g *ginContext) TooManyRequests(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.TooManyRequests)
+	g.respondError(http.StatusTooManyRequests, message, err, ginresponse.TooManyRequests)
 }
 
 func (g *ginContext) MethodNotAllowed(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.MethodNotAllowed)
+	g.respondError(http.StatusMethodNotAllowed, message, err, ginresponse.MethodNotAllowed)
 }
 
 func (g *ginContext) NotAcceptable(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.NotAcceptable)
+	g.respondError(http.StatusNotAcceptable, message, err, ginresponse.NotAcceptable)
 }
 
 // ServiceUnavailable Server error responses (5xx)
 func (g *ginContext) ServiceUnavailable(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.ServiceUnavailable)
+	g.respondError(http.StatusServiceUnavailable, message, err, ginresponse.ServiceUnavailable)
 }
 
 func (g *ginContext) InternalServerError(message string, err any) {
-	g.logAndRespond(g.logger.Ef, message, err, ginresponse.InternalServerError)
+	g.respondError(http.StatusInternalServerError, message, err, ginresponse.InternalServerError)
+}
+
+/* DOMAIN ERROR */
+
+func (g *ginContext) Error(message string, err error) {
+	domainErr, ok := domainerror.As(err)
+	if !ok {
+		var detail any
+		if err != nil {
+			detail = err.Error()
+		}
+		g.InternalServerError(message, detail)
+		return
+	}
+
+	cause := err.Error()
+	if domainErr.Kind == domainerror.UnauthorizedKind {
+		// la cause d'un refus d'authentification (token mal signé, expiré...) n'est pas exposée
+		cause = domainErr.Message
+	}
+	detail := []httpgateway.ErrorResponse{{
+		Message: domainErr.Message,
+		Type:    string(domainErr.Kind),
+		Field:   domainErr.Field,
+		Detail:  cause,
+	}}
+	switch domainErr.Kind {
+	case domainerror.NotFoundKind:
+		g.NotFound(domainErr.Message, detail)
+	case domainerror.ConflictKind:
+		g.Conflict(domainErr.Message, detail)
+	case domainerror.ValidationKind:
+		g.UnprocessableEntity(domainErr.Message, detail)
+	case domainerror.UnauthorizedKind:
+		g.Unauthorized(domainErr.Message, detail)
+	default:
+		g.InternalServerError(message, err.Error())
+	}
+}
+
+/* PROBLEM JSON */
+
+// problem réponse d'erreur RFC 7807, Errors et Error sont des membres d'extension
+type problem struct {
+	Type     string                      ` + "`json:\"type\"`" + `
+	Title    string                      ` + "`json:\"title\"`" + `
+	Status   int                         ` + "`json:\"status\"`" + `
+	Detail   string                      ` + "`json:\"detail\"`" + `
+	Instance string                      ` + "`json:\"instance\"`" + `
+	Errors   []httpgateway.ErrorResponse ` + "`json:\"errors,omitempty\"`" + `
+	Error    any                         ` + "`json:\"error,omitempty\"`" + `
+}
+
+// respondError répond une erreur au format ginresponse, ou RFC 7807 avec WithProblemJSON
+func (g *ginContext) respondError(status int, message string, err any, responseFn func(*gin.Context, string, any)) {
+	if !g.problemJSON {
+		g.logAndRespond(g.logger.Ef, message, err, responseFn)
+		return
+	}
+
+	g.logger.Ef(message, err)
+	p := problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   message,
+		Instance: g.c.Request.URL.Path,
+	}
+	switch e := err.(type) {
+	case []httpgateway.ErrorResponse:
+		p.Errors = e
+	case httpgateway.ErrorResponse:
+		p.Errors = []httpgateway.ErrorResponse{e}
+	case error:
+		p.Error = e.Error()
+	default:
+		p.Error = e
+	}
+	// le Content-Type défini ici n'est pas remplacé par c.JSON
+	g.c.Header("Content-Type", "application/problem+json")
+	g.c.JSON(status, p)
 }
 
 /* CENTRALISATION DES LOG ET RESPONSE */
@@ -187,5 +482,5 @@ func (g *ginContext) logAndRespond(
 	logFn(message, data)
 	responseFn(g.c, message, data)
 }
-`, moduleName, moduleName)
+`, moduleName, moduleName, moduleName)
 }
@@ -1,9 +1,16 @@
 package stage2
 
-func GitignoreApiContent() string {
-	return `tmp/*
+func GitignoreApiContent(opts Options) string {
+	content := `tmp/*
 !tmp/.gitkeep
 .idea
 .vscode
 `
+	if opts.Db == DbSqlite {
+		// fichiers de la base sqlite
+		content += `data/*
+!data/.gitkeep
+`
+	}
+	return content
 }
@@ -0,0 +1,63 @@
+package stage2
+
+import "fmt"
+
+func HealthControllerContent(moduleName string) string {
+	return fmt.Sprintf(`package healthcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+	"%s/internal/application/usecase/healthusecase"
+)
+
+// HealthController sondes des healthchecks docker, enregistrées hors de /api/v1
+type HealthController struct {
+	useCase   *healthusecase.HealthUseCase
+	prefixUrl string
+}
+
+// New crée une nouvelle instance du controller Health
+func New(useCase *healthusecase.HealthUseCase) *HealthController {
+	return &HealthController{
+		useCase:   useCase,
+		prefixUrl: "/health",
+	}
+}
+
+// RegisterRoutes enregistre les routes du controller
+func (c *HealthController) RegisterRoutes(r httpgateway.Router) {
+	r.Handle("GET", c.prefixUrl+"/live", c.Live)
+	r.Handle("GET", c.prefixUrl+"/ready", c.Ready)
+}
+`, moduleName, moduleName)
+}
+
+func HealthLiveContent(moduleName string) string {
+	return fmt.Sprintf(`package healthcontroller
+
+import "%s/internal/application/gateway/httpgateway"
+
+// Live répond tant que le serveur http tourne, sans vérifier les gateways
+func (c *HealthController) Live(ctx httpgateway.Context) {
+	ctx.Success("Api en vie", map[string]string{"status": "ok"})
+}
+`, moduleName)
+}
+
+func HealthReadyContent(moduleName string) string {
+	return fmt.Sprintf(`package healthcontroller
+
+import "%s/internal/application/gateway/httpgateway"
+
+// Ready répond 200 si tous les gateways (db, cache, mail, stockage) répondent, 503 sinon avec l'état de chacun
+func (c *HealthController) Ready(ctx httpgateway.Context) {
+	report, ok := c.useCase.Ready(ctx.Request().Context())
+	if !ok {
+		ctx.ServiceUnavailable("Api non prête", report)
+		return
+	}
+
+	ctx.Success("Api prête", report)
+}
+`, moduleName)
+}
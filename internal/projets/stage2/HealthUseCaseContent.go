@@ -0,0 +1,81 @@
+package stage2
+
+import "fmt"
+
+func HealthUseCaseContent(moduleName string) string {
+	return fmt.Sprintf(`package healthusecase
+
+import (
+	"context"
+	"%s/internal/application/gateway/loggateway"
+	"sync"
+	"time"
+)
+
+// Pinger gateway dont la connexion est vérifiée par Ready: dbgateway.Database, cachegateway.Cache,
+// mailergateway.Mailer, storagegateway.Storage
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Check gateway vérifié par Ready, Name est sa clé dans le rapport, ex: "db"
+type Check struct {
+	Name   string
+	Pinger Pinger
+}
+
+// Report état de l'api et de chaque gateway, "ok" ou "ko": /health/ready est public,
+// l'erreur du Ping (hôtes, endpoints) est journalisée mais jamais retournée
+type Report struct {
+	Status string            `+"`json:\"status\"`"+`
+	Checks map[string]string `+"`json:\"checks\"`"+`
+}
+
+// HealthUseCase vérifie les gateways dont dépend l'api
+type HealthUseCase struct {
+	logger  loggateway.Logger
+	checks  []Check
+	timeout time.Duration
+}
+
+// NewHealthUseCase crée une nouvelle instance du use case, chaque Ping est limité à timeout
+func NewHealthUseCase(logger loggateway.Logger, timeout time.Duration, checks ...Check) *HealthUseCase {
+	return &HealthUseCase{
+		logger:  logger,
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Ready vérifie tous les gateways en parallèle, ok vaut false si l'un d'eux ne répond pas
+func (uc *HealthUseCase) Ready(ctx context.Context) (Report, bool) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	report := Report{Status: "ok", Checks: make(map[string]string, len(uc.checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range uc.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status := "ok"
+			if err := check.Pinger.Ping(ctx); err != nil {
+				uc.logger.Ef("Healthcheck %%s : %%v", check.Name, err)
+				status = "ko"
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = status
+			if status != "ok" {
+				report.Status = "ko"
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report, report.Status == "ok"
+}
+`, moduleName)
+}
@@ -9,6 +9,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"%s/internal/application/gateway/loggateway"
+	"slices"
 )
 
 type ErrorResponse struct {
@@ -18,6 +19,14 @@ type ErrorResponse struct {
 	Detail  string ` + "`json:\"detail\"`" + `
 }
 
+// AuthUser utilisateur authentifié de la requête
+type AuthUser struct {
+	ID          string
+	Email       string
+	Roles       []string
+	Permissions []string
+}
+
 type Context interface {
 	Request() *http.Request
 	Param(name string) string
@@ -26,6 +35,14 @@ type Context interface {
 	SetHeader(name, value string)
 	Abort()
 
+	// BindJSON décode le corps JSON de la requête dans obj puis valide ses tags binding,
+	// répond 400 (corps invalide) ou 422 (une ErrorResponse par champ invalide) et retourne false si la requête est rejetée
+	BindJSON(obj any) bool
+	// BindQuery lit les paramètres query dans obj (tags form) puis le valide comme BindJSON
+	BindQuery(obj any) bool
+	// BindURI lit les paramètres de chemin dans obj (tags uri) puis le valide comme BindJSON
+	BindURI(obj any) bool
+
 	Success(message string, data any)
 	Created(message string, data any)
 	NoContent(message string)
@@ -40,15 +57,109 @@ type Context interface {
 	NotAcceptable(message string, err any)
 	ServiceUnavailable(message string, err any)
 	InternalServerError(message string, err any)
+	// Error répond selon la catégorie de l'erreur métier contenue dans err (domainerror):
+	// 404 NotFound, 409 Conflict, 422 Validation, 401 Unauthorized, 500 avec message sinon
+	Error(message string, err error)
 
 	FormFile(name string) (*multipart.FileHeader, error)
 	SaveUploadedFile(file *multipart.FileHeader, dst string) error
 
+	// SetUser enregistre l'utilisateur authentifié de la requête (middleware d'authentification)
+	SetUser(user AuthUser)
+	// User utilisateur authentifié de la requête, false si la requête n'est pas authentifiée
+	User() (AuthUser, bool)
+
 	Logger() loggateway.Logger
 }
 
+// Authenticator authentifie la requête d'une route protégée et enregistre l'utilisateur (ctx.SetUser),
+// retourne false après avoir répondu 401 si la requête n'est pas authentifiée
+type Authenticator func(ctx Context) bool
+
+// AllPermissions permission donnant accès à toutes les routes protégées, quels que soient leurs rôles et permissions
+const AllPermissions = "*"
+
+// Access exigences d'une route: authentification, un des rôles et toutes les permissions
+type Access struct {
+	Authenticated bool
+	Roles         []string
+	Permissions   []string
+}
+
+// RouteOption option d'une route passée à Router.Handle
+type RouteOption func(*Access)
+
+// Authenticated route réservée aux utilisateurs authentifiés
+func Authenticated() RouteOption {
+	return func(a *Access) {
+		a.Authenticated = true
+	}
+}
+
+// Roles route réservée aux utilisateurs ayant au moins un des rôles
+func Roles(roles ...string) RouteOption {
+	return func(a *Access) {
+		a.Authenticated = true
+		a.Roles = append(a.Roles, roles...)
+	}
+}
+
+// Permissions route réservée aux utilisateurs ayant toutes les permissions
+func Permissions(permissions ...string) RouteOption {
+	return func(a *Access) {
+		a.Authenticated = true
+		a.Permissions = append(a.Permissions, permissions...)
+	}
+}
+
+// NewAccess exigences d'une route à partir de ses options
+func NewAccess(options ...RouteOption) Access {
+	var a Access
+	for _, option := range options {
+		option(&a)
+	}
+	return a
+}
+
+// Allowed vérifie que l'utilisateur a au moins un des rôles et toutes les permissions de la route
+func (a Access) Allowed(user AuthUser) bool {
+	if slices.Contains(user.Permissions, AllPermissions) {
+		return true
+	}
+	if len(a.Roles) > 0 && !slices.ContainsFunc(a.Roles, func(role string) bool {
+		return slices.Contains(user.Roles, role)
+	}) {
+		return false
+	}
+	for _, permission := range a.Permissions {
+		if !slices.Contains(user.Permissions, permission) {
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware enveloppe les handlers d'un router (journalisation, limitation de débit...),
+// un middleware qui n'appelle pas next doit répondre lui-même
+//
+//	func(next func(Context)) func(Context) {
+//		return func(ctx Context) {
+//			// avant le handler
+//			next(ctx)
+//		}
+//	}
+type Middleware func(next func(Context)) func(Context)
+
 type Router interface {
-	Handle(method, path string, h func(Context))
+	// Use ajoute des middlewares aux routes enregistrées ensuite sur ce router et ses groupes
+	Use(middlewares ...Middleware)
+	// Group router des routes préfixées par prefix, avec les middlewares du router puis middlewares
+	Group(prefix string, middlewares ...Middleware) Router
+	// Handle enregistre une route, publique sans options:
+	//
+	//	r.Handle("GET", "/me", c.Me, httpgateway.Authenticated())
+	//	r.Handle("DELETE", "/articles/:id", c.Delete, httpgateway.Roles("admin"), httpgateway.Permissions("articles:delete"))
+	Handle(method, path string, h func(Context), options ...RouteOption)
 	NoRoute(h func(Context))
 	NoMethod(h func(Context))
 }
@@ -0,0 +1,113 @@
+package stage2
+
+import "fmt"
+
+func JwtAdapterContent(moduleName string) string {
+	return fmt.Sprintf(`package jwtadapter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"%s/internal/application/gateway/tokengateway"
+	"time"
+)
+
+type jwtAdapter struct {
+	secret []byte
+}
+
+// claims claims JWT: sub (id de l'utilisateur), exp, iat, email, roles et typ (access ou refresh)
+type claims struct {
+	Email string                 `+"`json:\"email\"`"+`
+	Roles []string               `+"`json:\"roles\"`"+`
+	Type  tokengateway.TokenType `+"`json:\"typ\"`"+`
+	jwt.RegisteredClaims
+}
+
+// New crée une nouvelle instance de l'adaptateur JWT, les tokens sont signés en HS256 avec secret (JWT_SECRET_KEY)
+func New(secret string) tokengateway.Tokens {
+	return &jwtAdapter{
+		secret: []byte(secret),
+	}
+}
+
+// Generate crée un token signé valable ttl
+func (j *jwtAdapter) Generate(c tokengateway.Claims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Email: c.Email,
+		Roles: c.Roles,
+		Type:  c.Type,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   c.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	})
+
+	signed, err := token.SignedString(j.secret)
+	if err != nil {
+		return "", fmt.Errorf("erreur de signature du token: %%w", err)
+	}
+	return signed, nil
+}
+
+// Parse vérifie la signature, l'expiration et le type d'un token
+func (j *jwtAdapter) Parse(token string, tokenType tokengateway.TokenType) (*tokengateway.Claims, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(t *jwt.Token) (any, error) {
+		return j.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, errors.Join(tokengateway.ErrInvalidToken, err)
+	}
+	if c.Type != tokenType || c.Subject == "" {
+		return nil, tokengateway.ErrInvalidToken
+	}
+
+	return &tokengateway.Claims{
+		UserID:    c.Subject,
+		Email:     c.Email,
+		Roles:     c.Roles,
+		Type:      c.Type,
+		ExpiresAt: c.ExpiresAt.Time,
+	}, nil
+}
+`, moduleName)
+}
+
+func BcryptAdapterContent(moduleName string) string {
+	return fmt.Sprintf(`package bcryptadapter
+
+import (
+	"golang.org/x/crypto/bcrypt"
+	"%s/internal/application/gateway/passwordgateway"
+)
+
+type bcryptAdapter struct {
+	cost int
+}
+
+// New crée une nouvelle instance de l'adaptateur bcrypt
+func New() passwordgateway.Hasher {
+	return &bcryptAdapter{
+		cost: bcrypt.DefaultCost,
+	}
+}
+
+// Hash hash un mot de passe avec bcrypt (72 octets maximum)
+func (b *bcryptAdapter) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Compare vérifie qu'un mot de passe correspond à son hash
+func (b *bcryptAdapter) Compare(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+`, moduleName)
+}
@@ -0,0 +1,42 @@
+package stage2
+
+// MailTemplatesGoContent package mailtemplates, les templates de mails sont embarqués dans le binaire de l'api
+func MailTemplatesGoContent() string {
+	return `package mailtemplates
+
+import "embed"
+
+// FS templates des mails: <nom>.html (html/template) et/ou <nom>.txt (text/template),
+// utilisés avec mailergateway.Message{Template: "<nom>", Data: ...}
+//
+//go:embed *.html *.txt
+var FS embed.FS
+`
+}
+
+// ContactMailHtmlContent template HTML du mail de contact
+func ContactMailHtmlContent() string {
+	return `<!DOCTYPE html>
+<html lang="fr">
+<head>
+    <meta charset="utf-8">
+    <title>Nouveau message de {{.Name}}</title>
+</head>
+<body style="font-family: Arial, sans-serif; color: #1f2937;">
+    <h1 style="font-size: 20px;">Nouveau message de {{.Name}}</h1>
+    <p><strong>Email:</strong> <a href="mailto:{{.Email}}">{{.Email}}</a></p>
+    <p style="white-space: pre-line;">{{.Message}}</p>
+</body>
+</html>
+`
+}
+
+// ContactMailTextContent template texte du mail de contact
+func ContactMailTextContent() string {
+	return `Nouveau message de {{.Name}}
+
+Email: {{.Email}}
+
+{{.Message}}
+`
+}
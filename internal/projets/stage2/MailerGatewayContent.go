@@ -0,0 +1,43 @@
+package stage2
+
+func MailerGatewayContent() string {
+	return `package mailergateway
+
+import "context"
+
+// Attachment pièce jointe d'un mail
+type Attachment struct {
+	Filename    string
+	ContentType string // déduit de l'extension du fichier si vide
+	Content     []byte
+}
+
+// Message mail à envoyer
+type Message struct {
+	To      []string
+	Cc      []string
+	Bcc     []string
+	ReplyTo string
+	Subject string
+
+	// Template nom du template de mailtemplates (<Template>.html et/ou <Template>.txt) rendu avec Data,
+	// remplace HTML et Text
+	Template string
+	Data     any
+
+	HTML string
+	Text string
+
+	Attachments []Attachment
+}
+
+// Mailer représente le gateway pour l'envoi de mails
+type Mailer interface {
+	// Ping vérifie que le serveur d'envoi répond
+	Ping(ctx context.Context) error
+
+	// Send envoie un mail, en HTML et/ou en texte, avec ses pièces jointes
+	Send(ctx context.Context, message Message) error
+}
+`
+}
@@ -1,55 +1,150 @@
 package stage2
 
-import "fmt"
-
-func MainGoContent(moduleName string) string {
-	return fmt.Sprintf(`package main
-
 import (
-	"context"
-	"errors"
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
-	"github.com/nsevenpack/env/env"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
-	"go.mongodb.org/mongo-driver/mongo"
-	"net/http"
-	"%s/docs"
-	"%s/internal/application/controller/nsevencontroller"
-	"%s/internal/application/controller/testcontroller"
-	"%s/internal/application/gateway/dbgateway"
-	"%s/internal/application/gateway/httpgateway"
-	"%s/internal/application/gateway/loggateway"
-	"%s/internal/application/usecase/nsevenusecase"
-	"%s/internal/infrastructure/adapter/ginadapter"
-	"%s/internal/infrastructure/adapter/loggeradapter"
-	"%s/internal/infrastructure/adapter/mongoadapter"
-	"%s/internal/infrastructure/repository/nsevenrepository"
-	"os"
-	"os/signal"
+	"fmt"
 	"strings"
-	"syscall"
-	"time"
 )
 
+func MainGoContent(moduleName string, opts Options) string {
+	imports := []string{
+		`"context"`,
+		`"errors"`,
+		`"github.com/gin-contrib/cors"`,
+		`"github.com/gin-gonic/gin"`,
+		`"github.com/nsevenpack/env/env"`,
+		`swaggerFiles "github.com/swaggo/files"`,
+		`ginSwagger "github.com/swaggo/gin-swagger"`,
+		`"net/http"`,
+		`"os"`,
+		`"os/signal"`,
+		`"strings"`,
+		`"syscall"`,
+		`"time"`,
+	}
+	for _, pkg := range []string{
+		"docs",
+		"internal/application/controller/healthcontroller",
+		"internal/application/controller/nsevencontroller",
+		"internal/application/controller/testcontroller",
+		"internal/application/gateway/dbgateway",
+		"internal/application/gateway/httpgateway",
+		"internal/application/gateway/loggateway",
+		"internal/application/usecase/healthusecase",
+		"internal/application/usecase/nsevenusecase",
+		"internal/infrastructure/adapter/ginadapter",
+		"internal/infrastructure/adapter/loggeradapter",
+		"internal/infrastructure/repository/nsevenrepository",
+	} {
+		imports = append(imports, fmt.Sprintf("%q", moduleName+"/"+pkg))
+	}
+
+	// connexion et repositories selon la base de données
+	newDbAdapter := `dbUri := env.Get("DB_URI")
+	dbName := env.Get("DB_NAME")
+	dbAdapter = mongoadapter.New(dbUri, dbName, loggerAdapter)`
+	repositories := `// Récupérer la base de données MongoDB
+	mongoDatabase := dbAdapter.GetClient().(*mongo.Client).Database(env.Get("DB_NAME"))
+
+	// Créer les index et validateurs déclarés par les entités
+	ensureSchemas(mongoDatabase, nseven.Schema)
+
+	// Initialiser les repositories
+	nsevenRepo := nsevenrepository.NewMongoNsevenRepository(mongoDatabase)`
+	migrate := ""
+	funcs := ""
+	if opts.Db.Sql() {
+		imports = append(imports, `"database/sql"`, fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/sqladapter"), fmt.Sprintf("%q", moduleName+"/migrations"))
+		newDbAdapter = `dbAdapter = sqladapter.New(env.Get("DB_URI"), loggerAdapter)`
+		repositories = fmt.Sprintf(`// Récupérer la base de données %s
+	sqlDatabase := dbAdapter.GetClient().(*sql.DB)
+
+	// Initialiser les repositories
+	nsevenRepo := nsevenrepository.NewSqlNsevenRepository(sqlDatabase)`, opts.Db.Label())
+		migrate = `
+
+	// migrations en attente, appliquées aussi par go run ./cmd/migrate up
+	if env.Get("MIGRATE_ON_START") != "false" {
+		if err := sqladapter.Migrate(ctx, dbAdapter.GetClient().(*sql.DB), migrations.FS, loggerAdapter); err != nil {
+			loggerAdapter.Ef("Impossible d'appliquer les migrations : %v", err)
+			os.Exit(1)
+		}
+	}`
+	} else {
+		imports = append(imports,
+			`"go.mongodb.org/mongo-driver/mongo"`,
+			fmt.Sprintf("%q", moduleName+"/internal/domain/nseven"),
+			fmt.Sprintf("%q", moduleName+"/internal/domain/schema"),
+			fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/mongoadapter"),
+			fmt.Sprintf("%q", moduleName+"/migrations"),
+		)
+		migrate = `
+
+	// migrations en attente, appliquées aussi par go run ./cmd/migrate up
+	if env.Get("MIGRATE_ON_START") != "false" {
+		database := dbAdapter.GetClient().(*mongo.Client).Database(env.Get("DB_NAME"))
+		if err := mongoadapter.Migrate(ctx, database, migrations.All(), loggerAdapter); err != nil {
+			loggerAdapter.Ef("Impossible d'appliquer les migrations : %v", err)
+			os.Exit(1)
+		}
+	}`
+		funcs = `
+// ensureSchemas applique les index et validateurs déclarés par les entités (var Schema de leur package domain)
+func ensureSchemas(database *mongo.Database, collections ...schema.Collection) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := mongoadapter.EnsureSchemas(ctx, database, loggerAdapter, collections...); err != nil {
+		loggerAdapter.Ef("Impossible d'appliquer le schéma MongoDB : %v", err)
+		os.Exit(1)
+	}
+}
+`
+	}
+
+	vars := []string{"loggerAdapter loggateway.Logger", "dbAdapter dbgateway.Database"}
+	var inits, starts, useCases, controllers, routerOptions string
+	healthChecks := "\n\t\t{Name: \"db\", Pinger: dbAdapter},"
+	for _, feature := range mainFeatures(moduleName, opts) {
+		imports = append(imports, feature.imports...)
+		vars = append(vars, feature.vars)
+		inits += "\n\t" + feature.init
+		if feature.start != "" {
+			starts += "\n\t" + feature.start
+		}
+		funcs += feature.funcs
+		if feature.healthCheck != "" {
+			healthChecks += "\n\t\t" + feature.healthCheck + ","
+		}
+		if feature.routerOption != "" {
+			routerOptions += ", " + feature.routerOption
+		}
+		if feature.useCase != "" {
+			useCases += "\n\t" + feature.useCase
+			controllers += "\n\t\t" + feature.controller + ","
+		}
+	}
+
+	return fmt.Sprintf(`package main
+
+%s
+
 var (
-	loggerAdapter loggateway.Logger
-	dbAdapter     dbgateway.Database
+%s
 )
 
 // @title nseven api
 // @version 1.0
 // @description API service nseven api
 // @schemes https
+// @BasePath /api/v1
 // @securityDefinitions.apikey BearerAuth
-// @in headers
+// @in header
 // @name Authorization
 func main() {
 	ctx := context.Background()
 
 	initDatabase(ctx)
-	defer closeDatabase(ctx)
+	defer closeDatabase(ctx)%s
 
 	s := gin.Default()
 	host := "0.0.0.0"
@@ -68,36 +163,41 @@ func init() {
 	appEnv := env.Get("APP_ENV")
 	loggerAdapter = loggeradapter.New(appEnv)
 
-	dbUri := env.Get("DB_URI")
-	dbName := env.Get("DB_NAME")
-	dbAdapter = mongoadapter.New(dbUri, dbName, loggerAdapter)
+	%s%s
 }
 
 func router(s *gin.Engine) {
-	ginAdapter := ginadapter.New(s, loggerAdapter)
+	ginAdapter := ginadapter.New(s, loggerAdapter, ginadapter.WithProblemJSON(env.Get("PROBLEM_JSON") == "true")%s)
 	s.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// sondes des healthchecks docker, hors de /api/v1: /health/live et /health/ready
+	healthUseCase := healthusecase.NewHealthUseCase(loggerAdapter, 3*time.Second, getHealthChecks()...)
+	healthcontroller.New(healthUseCase).RegisterRoutes(ginAdapter)
+
+	apiV1 := ginAdapter.Group("/api/v1")
 	for _, m := range getControllers() {
-		m.RegisterRoutes(ginAdapter)
+		m.RegisterRoutes(apiV1)
 	}
 
 	setupErrorHandlers(ginAdapter)
 }
 
 func getControllers() []httpgateway.Routable {
-	// Récupérer la base de données MongoDB
-	mongoDatabase := dbAdapter.GetClient().(*mongo.Client).Database(env.Get("DB_NAME"))
-
-	// Initialiser les repositories
-	nsevenRepo := nsevenrepository.NewMongoNsevenRepository(mongoDatabase)
+	%s
 
 	// Initialiser les use cases
-	nsevenUseCase := nsevenusecase.NewNsevenUseCase(nsevenRepo)
+	nsevenUseCase := nsevenusecase.NewNsevenUseCase(nsevenRepo)%s
 
 	// Retourner les controllers
 	return []httpgateway.Routable{
 		testcontroller.New(),
-		nsevencontroller.New(nsevenUseCase),
+		nsevencontroller.New(nsevenUseCase),%s
+	}
+}
+
+// getHealthChecks gateways vérifiés par /health/ready
+func getHealthChecks() []healthusecase.Check {
+	return []healthusecase.Check{%s
 	}
 }
 
@@ -136,17 +236,17 @@ func infoServer(hostTraefikApi string) {
 
 func initDatabase(ctx context.Context) {
 	if err := dbAdapter.Connect(ctx); err != nil {
-		loggerAdapter.Ef("Impossible de se connecter à MongoDB : %%v", err)
+		loggerAdapter.Ef("Impossible de se connecter à %s : %%v", err)
 		os.Exit(1)
-	}
+	}%s
 }
 
 func closeDatabase(ctx context.Context) {
 	if err := dbAdapter.Disconnect(ctx); err != nil {
-		loggerAdapter.Ef("Erreur lors de la déconnexion de MongoDB : %%v", err)
+		loggerAdapter.Ef("Erreur lors de la déconnexion de %s : %%v", err)
 	}
 }
-
+%s
 func startServerWithGracefulShutdown(s *gin.Engine, host, port string) {
 	srv := &http.Server{
 		Addr:    host + ":" + port,
@@ -183,5 +283,144 @@ func extractStringInBacktick(s string) string {
 
 	return s[start+1 : end]
 }
-`, moduleName, moduleName, moduleName, moduleName, moduleName, moduleName, moduleName, moduleName, moduleName, moduleName, moduleName)
+`, importBlock(imports), varBlock(vars), starts, newDbAdapter, inits, routerOptions, repositories, useCases, controllers, healthChecks, opts.Db.Label(), migrate, opts.Db.Label(), funcs)
+}
+
+// mainFeature code du main.go d'une fonctionnalité choisie à la création du projet
+type mainFeature struct {
+	imports []string
+	vars    string // variable globale de l'adapter: "<nom> <type>"
+	init    string // création de l'adapter dans init()
+	start   string // connexion au lancement dans main()
+	funcs   string // fonctions de connexion et de déconnexion
+
+	routerOption string // option de ginadapter.New dans router()
+	healthCheck  string // gateway vérifié par /health/ready, ex: {Name: "cache", Pinger: cacheAdapter}
+
+	useCase    string // use case d'exemple dans getControllers()
+	controller string // controller du use case d'exemple
+}
+
+func mainFeatures(moduleName string, opts Options) []mainFeature {
+	var features []mainFeature
+	if opts.Cache {
+		features = append(features, mainFeature{
+			imports: []string{
+				fmt.Sprintf("%q", moduleName+"/internal/application/gateway/cachegateway"),
+				fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/redisadapter"),
+			},
+			vars:        "cacheAdapter cachegateway.Cache",
+			healthCheck: `{Name: "cache", Pinger: cacheAdapter}`,
+			init:        `cacheAdapter = redisadapter.New(env.Get("REDIS_ADDR"), env.Get("REDIS_PASSWORD"), loggerAdapter)`,
+			start:       "initCache(ctx)\n\tdefer closeCache(ctx)",
+			funcs: `
+func initCache(ctx context.Context) {
+	if err := cacheAdapter.Connect(ctx); err != nil {
+		loggerAdapter.Ef("Impossible de se connecter à Redis : %v", err)
+		os.Exit(1)
+	}
+}
+
+func closeCache(ctx context.Context) {
+	if err := cacheAdapter.Disconnect(ctx); err != nil {
+		loggerAdapter.Ef("Erreur lors de la déconnexion de Redis : %v", err)
+	}
+}
+`,
+		})
+	}
+	if opts.Mailer {
+		features = append(features, mainFeature{
+			imports: []string{
+				fmt.Sprintf("%q", moduleName+"/internal/application/controller/contactcontroller"),
+				fmt.Sprintf("%q", moduleName+"/internal/application/gateway/mailergateway"),
+				fmt.Sprintf("%q", moduleName+"/internal/application/usecase/contactusecase"),
+				fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/smtpadapter"),
+				fmt.Sprintf("%q", moduleName+"/mailtemplates"),
+			},
+			vars:        "mailerAdapter mailergateway.Mailer",
+			healthCheck: `{Name: "mail", Pinger: mailerAdapter}`,
+			init: `mailerAdapter = smtpadapter.New(smtpadapter.Config{
+		Host:     env.Get("MAIL_HOST"),
+		Port:     env.Get("MAIL_PORT"),
+		User:     env.Get("MAIL_USER"),
+		Password: env.Get("MAIL_PASS"),
+		From:     env.Get("MAIL_FROM"),
+	}, mailtemplates.FS, loggerAdapter)`,
+			useCase:    `contactUseCase := contactusecase.NewContactUseCase(mailerAdapter, env.Get("MAIL_CONTACT"))`,
+			controller: "contactcontroller.New(contactUseCase)",
+		})
+	}
+	if opts.Storage {
+		features = append(features, mainFeature{
+			imports: []string{
+				fmt.Sprintf("%q", moduleName+"/internal/application/controller/uploadcontroller"),
+				fmt.Sprintf("%q", moduleName+"/internal/application/gateway/storagegateway"),
+				fmt.Sprintf("%q", moduleName+"/internal/application/usecase/uploadusecase"),
+				fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/s3adapter"),
+			},
+			vars:        "storageAdapter storagegateway.Storage",
+			healthCheck: `{Name: "storage", Pinger: storageAdapter}`,
+			init: `storageAdapter = s3adapter.New(s3adapter.Config{
+		Endpoint:        env.Get("R2_ENDPOINT"),
+		PublicEndpoint:  env.Get("R2_PUBLIC_ENDPOINT"),
+		AccountID:       env.Get("R2_ACCOUNT_ID"),
+		AccessKeyID:     env.Get("R2_ACCESS_KEY_ID"),
+		SecretAccessKey: env.Get("R2_SECRET_ACCESS_KEY"),
+		Bucket:          env.Get("R2_BUCKET_NAME"),
+		UseSSL:          env.Get("R2_USE_SSL") != "false",
+	}, loggerAdapter)`,
+			start: "initStorage(ctx)",
+			funcs: `
+func initStorage(ctx context.Context) {
+	if err := storageAdapter.Connect(ctx); err != nil {
+		loggerAdapter.Ef("Impossible de se connecter au stockage S3 : %v", err)
+		os.Exit(1)
+	}
+}
+`,
+			useCase:    "uploadUseCase := uploadusecase.NewUploadUseCase(storageAdapter, 15*time.Minute)",
+			controller: "uploadcontroller.New(uploadUseCase)",
+		})
+	}
+	if opts.Auth {
+		userRepo := "ensureSchemas(mongoDatabase, user.Schema)\n\tuserRepo := userrepository.NewMongoUserRepository(mongoDatabase)"
+		userImports := []string{fmt.Sprintf("%q", moduleName+"/internal/domain/user")}
+		if opts.Db.Sql() {
+			userRepo = "userRepo := userrepository.NewSqlUserRepository(sqlDatabase)"
+			userImports = nil
+		}
+		features = append(features, mainFeature{
+			imports: append(userImports,
+				fmt.Sprintf("%q", moduleName+"/internal/application/controller/authcontroller"),
+				fmt.Sprintf("%q", moduleName+"/internal/application/gateway/tokengateway"),
+				fmt.Sprintf("%q", moduleName+"/internal/application/middleware/authmiddleware"),
+				fmt.Sprintf("%q", moduleName+"/internal/application/usecase/authusecase"),
+				fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/bcryptadapter"),
+				fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/jwtadapter"),
+				fmt.Sprintf("%q", moduleName+"/internal/infrastructure/repository/userrepository"),
+			),
+			vars:         "tokenAdapter tokengateway.Tokens",
+			init:         `tokenAdapter = jwtadapter.New(env.Get("JWT_SECRET_KEY"))`,
+			routerOption: "ginadapter.WithAuthenticator(authmiddleware.New(tokenAdapter).Authenticate)",
+			useCase: fmt.Sprintf(`%s
+	authUseCase := authusecase.NewAuthUseCase(userRepo, bcryptadapter.New(), tokenAdapter)`, userRepo),
+			controller: "authcontroller.New(authUseCase)",
+		})
+	}
+	return features
+}
+
+// varBlock déclarations "<nom> <type>" du bloc var alignées comme gofmt
+func varBlock(vars []string) string {
+	width := 0
+	for _, v := range vars {
+		width = max(width, strings.Index(v, " "))
+	}
+	lines := make([]string, len(vars))
+	for i, v := range vars {
+		name, typ, _ := strings.Cut(v, " ")
+		lines[i] = fmt.Sprintf("\t%-*s %s", width, name, typ)
+	}
+	return strings.Join(lines, "\n")
 }
@@ -2,7 +2,7 @@ package stage2
 
 import "fmt"
 
-func MakefileContent(nameFolderProject string) string {
+func MakefileContent(nameFolderProject string, opts Options) string {
 	return fmt.Sprintf(`-include .env
 
 # Redefinir MAKEFILE_LIST pour qu'il ne contienne que le Makefile
@@ -18,8 +18,10 @@ NC = \033[0m # No Color
 # Variables
 COMPOSE_FILE = $(if $(filter $(APP_ENV),prod),docker/compose.prod.yaml,$(if $(filter $(APP_ENV),preprod),docker/compose.preprod.yaml,docker/compose.yaml))
 DOCKER_COMPOSE = docker compose $(ENV_FILE) -f $(COMPOSE_FILE)
+# binaire migrate de l'image en prod et preprod, sources en dev
+MIGRATE = $(if $(filter $(APP_ENV),prod preprod),./migrate,go run ./cmd/migrate)
 
-.PHONY: help build up down logs shell restart clean status ps ta tap tav tavp tf tfv
+.PHONY: help build up down logs shell restart clean status ps ta tap tav tavp tf tfv migrate-create migrate-up migrate-down migrate-status
 
 help: ## Affiche cette aide
 	@echo ""
@@ -65,19 +67,25 @@ lfront: ## logs front
 lapi: ## logs api
 	$(DOCKER_COMPOSE) logs -f api
 
-ldb: ## logs db
-	$(DOCKER_COMPOSE) logs -f db
-
-shfront: ## shell conteneur front
+%sshfront: ## shell conteneur front
 	$(DOCKER_COMPOSE) exec front bash
 
 shapi: ## shell conteneur api
 	$(DOCKER_COMPOSE) exec api bash
 
-shdb: ## shell conteneur db mongo
-	$(DOCKER_COMPOSE) exec db mongosh
+migrate-create: ## crée une migration en dev (usage: make migrate-create name=add_users)
+	$(DOCKER_COMPOSE) exec api go run ./cmd/migrate create $(name)
+
+migrate-up: ## applique les migrations en attente
+	$(DOCKER_COMPOSE) exec api $(MIGRATE) up
+
+migrate-down: ## annule les dernières migrations (usage: make migrate-down n=1)
+	$(DOCKER_COMPOSE) exec api $(MIGRATE) down $(or $(n),1)
 
-ta: ## Lance tous les tests api
+migrate-status: ## état des migrations (appliquées, en attente)
+	$(DOCKER_COMPOSE) exec api $(MIGRATE) status
+
+%s%sta: ## Lance tous les tests api
 	docker exec -i -e APP_ENV=test %s_dev_api go test ./...
 
 tai: ## Lance tous les tests api d'integration avec logs (fmt-print)
@@ -101,13 +109,82 @@ tavp: ## Lance les tests api en verbose pour un path (usage: make tavp path=monp
 taivp: ## Lance les tests api en verbose + integration pour un path (usage: make tavp path=monpath)
 	docker exec -i -e APP_ENV=test %s_dev_api go test -v -tags=integration ./$(path)
 `,
-		nameFolderProject, // ta
-		nameFolderProject, // tai
-		nameFolderProject, // tap
-		nameFolderProject, // taip
-		nameFolderProject, // tav
-		nameFolderProject, // taiv
-		nameFolderProject, // tavp
-		nameFolderProject, // taivp
+		makefileLogsContent(opts),  // ldb, lredis, lmailpit, lminio
+		makefileAdminContent(opts), // admin-promote
+		makefileShellContent(opts), // shdb, shredis
+		nameFolderProject,          // ta
+		nameFolderProject,          // tai
+		nameFolderProject,          // tap
+		nameFolderProject,          // taip
+		nameFolderProject,          // tav
+		nameFolderProject,          // taiv
+		nameFolderProject,          // tavp
+		nameFolderProject,          // taivp
 	)
 }
+
+// makefileLogsContent logs des conteneurs db, redis, mailpit et minio (dev), sqlite n'a pas de conteneur db
+func makefileLogsContent(opts Options) string {
+	content := ""
+	if opts.Db != DbSqlite {
+		content += `ldb: ## logs db
+	$(DOCKER_COMPOSE) logs -f db
+
+`
+	}
+	if opts.Cache {
+		content += `lredis: ## logs redis
+	$(DOCKER_COMPOSE) logs -f redis
+
+`
+	}
+	if opts.Mailer {
+		content += `lmailpit: ## logs mailpit (dev)
+	$(DOCKER_COMPOSE) logs -f mailpit
+
+`
+	}
+	if opts.Storage {
+		content += `lminio: ## logs minio (dev)
+	$(DOCKER_COMPOSE) logs -f minio
+
+`
+	}
+	return content
+}
+
+// makefileShellContent client de la base dans le conteneur db et redis-cli
+func makefileShellContent(opts Options) string {
+	content := ""
+	switch opts.Db {
+	case DbMongo:
+		content += `shdb: ## shell conteneur db mongo
+	$(DOCKER_COMPOSE) exec db mongosh
+
+`
+	case DbPostgres:
+		content += `shdb: ## shell conteneur db postgres
+	$(DOCKER_COMPOSE) exec db sh -c 'psql -U "$$POSTGRES_USER"'
+
+`
+	}
+	if opts.Cache {
+		content += `shredis: ## shell conteneur redis
+	$(DOCKER_COMPOSE) exec redis redis-cli
+
+`
+	}
+	return content
+}
+
+// makefileAdminContent promotion du premier admin avec --auth, binaire admin de l'image en prod et preprod
+func makefileAdminContent(opts Options) string {
+	if !opts.Auth {
+		return ""
+	}
+	return `.PHONY: admin-promote
+admin-promote: ## donne le rôle admin à un utilisateur inscrit (usage: make admin-promote email=admin@example.com)
+	$(DOCKER_COMPOSE) exec api $(if $(filter $(APP_ENV),prod preprod),./admin,go run ./cmd/admin) promote $(email)
+
+`
+}
@@ -0,0 +1,613 @@
+package stage2
+
+import "fmt"
+
+// SqlMigrateContent application et annulation des migrations SQL du package migrations, suivies dans la table schema_migrations
+func SqlMigrateContent(moduleName string, db Db) string {
+	insertMigration, deleteMigration := "INSERT INTO schema_migrations (version, applied_at) VALUES ($1, $2)", "DELETE FROM schema_migrations WHERE version = $1"
+	if db == DbSqlite {
+		insertMigration, deleteMigration = "INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)", "DELETE FROM schema_migrations WHERE version = ?"
+	}
+	// PostgreSQL: verrou des répliques de l'api qui démarrent en même temps, SQLite n'a qu'un écrivain
+	lockCall, lockFunc := "", ""
+	if db == DbPostgres {
+		lockCall = `
+	unlock, err := lock(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+`
+		lockFunc = `
+const (
+	// lockKey clé du verrou consultatif PostgreSQL des migrations
+	lockKey = 7305646
+	// lockWait attente maximale du verrou tenu par un autre processus
+	lockWait = 2 * time.Minute
+)
+
+// lock prend le verrou consultatif des migrations sur une connexion dédiée, les autres processus attendent qu'il soit rendu
+// par unlock (ou par la fin de la connexion si le processus s'arrête pendant une migration)
+func lock(ctx context.Context, db *sql.DB) (func(), error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connexion du verrou des migrations: %w", err)
+	}
+	lockCtx, cancel := context.WithTimeout(ctx, lockWait)
+	defer cancel()
+	if _, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("verrou des migrations non obtenu après %s: %w", lockWait, err)
+	}
+	return func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+		_ = conn.Close()
+	}, nil
+}
+`
+	}
+
+	return fmt.Sprintf(`package sqladapter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"%[1]s/internal/application/gateway/loggateway"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MigrationState état d'une migration
+type MigrationState struct {
+	Version   string
+	AppliedAt *time.Time // nil si la migration est en attente
+}
+
+// Migrate applique dans l'ordre des noms de fichiers les migrations <version>.up.sql pas encore appliquées,
+// chaque migration appliquée est enregistrée dans la table schema_migrations
+func Migrate(ctx context.Context, db *sql.DB, migrations fs.FS, logger loggateway.Logger) error {%[4]s
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+	versions, err := migrationVersions(migrations)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		if _, ok := applied[version]; ok {
+			continue
+		}
+		if err := runMigration(ctx, db, migrations, version+".up.sql", "%[2]s", version, time.Now().UTC()); err != nil {
+			return fmt.Errorf("migration %%s: %%w", version, err)
+		}
+		logger.If("Migration %%s appliquée", version)
+	}
+
+	return nil
+}
+
+// Rollback annule les steps dernières migrations appliquées avec leur fichier <version>.down.sql, de la plus récente à la plus ancienne
+func Rollback(ctx context.Context, db *sql.DB, migrations fs.FS, steps int, logger loggateway.Logger) error {%[4]s
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return err
+	}
+	versions := make([]string, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+
+	for _, version := range versions[:min(steps, len(versions))] {
+		if err := runMigration(ctx, db, migrations, version+".down.sql", "%[3]s", version); err != nil {
+			return fmt.Errorf("annulation de la migration %%s: %%w", version, err)
+		}
+		logger.If("Migration %%s annulée", version)
+	}
+
+	return nil
+}
+
+// MigrationStatus état des migrations du package migrations et de celles appliquées absentes du package
+func MigrationStatus(ctx context.Context, db *sql.DB, migrations fs.FS) ([]MigrationState, error) {
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := migrationVersions(migrations)
+	if err != nil {
+		return nil, err
+	}
+	for version := range applied {
+		if !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+
+	states := make([]MigrationState, len(versions))
+	for i, version := range versions {
+		states[i] = MigrationState{Version: version}
+		if appliedAt, ok := applied[version]; ok {
+			states[i].AppliedAt = &appliedAt
+		}
+	}
+	return states, nil
+}
+
+// runMigration exécute le fichier file et la requête de suivi record dans une transaction
+func runMigration(ctx context.Context, db *sql.DB, migrations fs.FS, file, record string, args ...any) error {
+	query, err := fs.ReadFile(migrations, file)
+	if err != nil {
+		return fmt.Errorf("lecture de %%s: %%w", file, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, string(query)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// appliedMigrations versions et dates des migrations appliquées, crée la table schema_migrations si besoin
+func appliedMigrations(ctx context.Context, db *sql.DB) (map[string]time.Time, error) {
+	if _, err := db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version TEXT PRIMARY KEY, applied_at TIMESTAMP NOT NULL)"); err != nil {
+		return nil, fmt.Errorf("création de la table schema_migrations: %%w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("lecture de schema_migrations: %%w", err)
+	}
+	defer rows.Close()
+
+	applied := map[string]time.Time{}
+	for rows.Next() {
+		var version string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("lecture de schema_migrations: %%w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("lecture de schema_migrations: %%w", err)
+	}
+	return applied, nil
+}
+
+// migrationVersions versions des fichiers <version>.up.sql triées
+func migrationVersions(migrations fs.FS) ([]string, error) {
+	files, err := fs.Glob(migrations, "*.up.sql")
+	if err != nil {
+		return nil, fmt.Errorf("lecture des migrations: %%w", err)
+	}
+	versions := make([]string, len(files))
+	for i, file := range files {
+		versions[i] = strings.TrimSuffix(file, ".up.sql")
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+%[5]s`, moduleName, insertMigration, deleteMigration, lockCall, lockFunc)
+}
+
+// MongoMigrateContent application et annulation des migrations Go du package migrations, suivies dans la collection schema_migrations
+func MongoMigrateContent(moduleName string) string {
+	return fmt.Sprintf(`package mongoadapter
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"%s/internal/application/gateway/loggateway"
+	"slices"
+	"sort"
+	"time"
+)
+
+// migrationsCollection collection de suivi des migrations appliquées
+const migrationsCollection = "schema_migrations"
+
+const (
+	// lockCollection verrou des migrations, un document d'_id lockID inséré par le processus qui migre
+	// (plusieurs répliques de l'api qui démarrent en même temps)
+	lockCollection = "schema_migrations_lock"
+	lockID         = "migrate"
+	// lockWait attente maximale du verrou tenu par un autre processus
+	lockWait = 2 * time.Minute
+	// lockStale âge au-delà duquel un verrou est abandonné (processus arrêté pendant une migration) et peut être repris
+	lockStale = 15 * time.Minute
+)
+
+// Migration migration MongoDB écrite en Go, enregistrée par un fichier du package migrations
+type Migration struct {
+	Version string // <numéro>_<nom>, ex: "0001_backfill_slugs", les migrations sont appliquées dans l'ordre des versions
+	Up      func(ctx context.Context, database *mongo.Database) error
+	Down    func(ctx context.Context, database *mongo.Database) error // nil si la migration ne peut pas être annulée
+}
+
+// MigrationState état d'une migration
+type MigrationState struct {
+	Version   string
+	AppliedAt *time.Time // nil si la migration est en attente
+}
+
+// appliedMigration document de la collection schema_migrations
+type appliedMigration struct {
+	Version   string    `+"`bson:\"_id\"`"+`
+	AppliedAt time.Time `+"`bson:\"appliedAt\"`"+`
+}
+
+// Migrate applique dans l'ordre des versions les migrations pas encore appliquées, chacune enregistrée dans schema_migrations,
+// sans transaction (MongoDB sans replica set): une migration en erreur reste en attente et doit pouvoir être relancée.
+// Le verrou des migrations est tenu pendant toute l'opération, un autre processus attend puis ne rejoue pas les migrations appliquées
+func Migrate(ctx context.Context, database *mongo.Database, migrations []Migration, logger loggateway.Logger) error {
+	return withLock(ctx, database, logger, func() error {
+		return migrate(ctx, database, migrations, logger)
+	})
+}
+
+func migrate(ctx context.Context, database *mongo.Database, migrations []Migration, logger loggateway.Logger) error {
+	applied, err := appliedMigrations(ctx, database)
+	if err != nil {
+		return err
+	}
+
+	sorted := slices.Clone(migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for _, migration := range sorted {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := migration.Up(ctx, database); err != nil {
+			return fmt.Errorf("migration %%s: %%w", migration.Version, err)
+		}
+		record := appliedMigration{Version: migration.Version, AppliedAt: time.Now().UTC()}
+		if _, err := database.Collection(migrationsCollection).InsertOne(ctx, record); err != nil {
+			return fmt.Errorf("migration %%s: enregistrement: %%w", migration.Version, err)
+		}
+		logger.If("Migration %%s appliquée", migration.Version)
+	}
+
+	return nil
+}
+
+// Rollback annule les steps dernières migrations appliquées avec leur Down, de la plus récente à la plus ancienne,
+// en tenant le verrou des migrations comme Migrate
+func Rollback(ctx context.Context, database *mongo.Database, migrations []Migration, steps int, logger loggateway.Logger) error {
+	return withLock(ctx, database, logger, func() error {
+		return rollback(ctx, database, migrations, steps, logger)
+	})
+}
+
+func rollback(ctx context.Context, database *mongo.Database, migrations []Migration, steps int, logger loggateway.Logger) error {
+	applied, err := appliedMigrations(ctx, database)
+	if err != nil {
+		return err
+	}
+	versions := make([]string, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+
+	for _, version := range versions[:min(steps, len(versions))] {
+		position := slices.IndexFunc(migrations, func(m Migration) bool { return m.Version == version })
+		if position < 0 || migrations[position].Down == nil {
+			return fmt.Errorf("annulation de la migration %%s: Down absent du package migrations", version)
+		}
+		if err := migrations[position].Down(ctx, database); err != nil {
+			return fmt.Errorf("annulation de la migration %%s: %%w", version, err)
+		}
+		if _, err := database.Collection(migrationsCollection).DeleteOne(ctx, bson.M{"_id": version}); err != nil {
+			return fmt.Errorf("annulation de la migration %%s: suppression du suivi: %%w", version, err)
+		}
+		logger.If("Migration %%s annulée", version)
+	}
+
+	return nil
+}
+
+// MigrationStatus état des migrations du package migrations et de celles appliquées absentes du package
+func MigrationStatus(ctx context.Context, database *mongo.Database, migrations []Migration) ([]MigrationState, error) {
+	applied, err := appliedMigrations(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(migrations))
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	for version := range applied {
+		if !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+
+	states := make([]MigrationState, len(versions))
+	for i, version := range versions {
+		states[i] = MigrationState{Version: version}
+		if appliedAt, ok := applied[version]; ok {
+			states[i].AppliedAt = &appliedAt
+		}
+	}
+	return states, nil
+}
+
+// withLock exécute fn en tenant le verrou des migrations: l'_id unique du document empêche deux processus de l'insérer,
+// le processus qui ne l'obtient pas réessaie chaque seconde pendant lockWait
+func withLock(ctx context.Context, database *mongo.Database, logger loggateway.Logger, fn func() error) error {
+	locks := database.Collection(lockCollection)
+	owner := primitive.NewObjectID()
+	deadline := time.Now().Add(lockWait)
+	for waiting := false; ; waiting = true {
+		_, err := locks.InsertOne(ctx, bson.M{"_id": lockID, "owner": owner, "lockedAt": time.Now().UTC()})
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("verrou des migrations: %%w", err)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("verrou des migrations tenu par un autre processus depuis plus de %%s (collection %%s)", lockWait, lockCollection)
+		}
+		if !waiting {
+			logger.Wf("Migrations en cours dans un autre processus, attente du verrou")
+		}
+
+		// verrou abandonné par un processus arrêté pendant une migration
+		stale := bson.M{"_id": lockID, "lockedAt": bson.M{"$lt": time.Now().UTC().Add(-lockStale)}}
+		if _, err := locks.DeleteOne(ctx, stale); err != nil {
+			return fmt.Errorf("verrou des migrations: %%w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	defer func() {
+		// contexte détaché: le verrou est libéré même si ctx est annulé, seulement s'il appartient toujours à ce processus
+		if _, err := locks.DeleteOne(context.Background(), bson.M{"_id": lockID, "owner": owner}); err != nil {
+			logger.Ef("Libération du verrou des migrations : %%v", err)
+		}
+	}()
+
+	return fn()
+}
+
+// appliedMigrations versions et dates des migrations appliquées
+func appliedMigrations(ctx context.Context, database *mongo.Database) (map[string]time.Time, error) {
+	cursor, err := database.Collection(migrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %%s: %%w", migrationsCollection, err)
+	}
+	var records []appliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, fmt.Errorf("lecture de %%s: %%w", migrationsCollection, err)
+	}
+
+	applied := map[string]time.Time{}
+	for _, record := range records {
+		applied[record.Version] = record.AppliedAt
+	}
+	return applied, nil
+}
+`, moduleName)
+}
+
+// MongoMigrationsGoContent package migrations MongoDB, chaque fichier <numéro>_<nom>.go enregistre une migration
+func MongoMigrationsGoContent(moduleName string) string {
+	return fmt.Sprintf(`package migrations
+
+import "%s/internal/infrastructure/adapter/mongoadapter"
+
+// all migrations enregistrées par les fichiers <numéro>_<nom>.go du package, créés avec go run ./cmd/migrate create <nom>
+var all []mongoadapter.Migration
+
+// register enregistre une migration, appelé par init() de chaque fichier de migration
+func register(migration mongoadapter.Migration) {
+	all = append(all, migration)
+}
+
+// All migrations MongoDB de l'api, appliquées au démarrage (MIGRATE_ON_START) ou par cmd/migrate
+func All() []mongoadapter.Migration {
+	return all
+}
+`, moduleName)
+}
+
+// MigrateCmdContent commande cmd/migrate de l'api: up, down, status et create
+func MigrateCmdContent(moduleName string, db Db) string {
+	imports := []string{
+		`"context"`,
+		`"fmt"`,
+		`"github.com/nsevenpack/env/env"`,
+		fmt.Sprintf("%q", moduleName+"/internal/application/gateway/loggateway"),
+		fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/loggeradapter"),
+		fmt.Sprintf("%q", moduleName+"/migrations"),
+		`"os"`,
+		`"path/filepath"`,
+		`"regexp"`,
+		`"strconv"`,
+		`"strings"`,
+		`"time"`,
+	}
+	adapter := "mongoadapter"
+	newAdapter := `mongoadapter.New(env.Get("DB_URI"), env.Get("DB_NAME"), logger)`
+	client := `database := dbAdapter.GetClient().(*mongo.Client).Database(env.Get("DB_NAME"))`
+	source := "database, migrations.All()"
+	files := `map[string]string{
+		version + ".go": fmt.Sprintf(mongoMigration, version),
+	}`
+	templates := fmt.Sprintf(`
+// mongoMigration fichier d'une migration MongoDB, enregistrée dans le package migrations par init()
+const mongoMigration = `+"`"+`package migrations
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/mongo"
+	"%s/internal/infrastructure/adapter/mongoadapter"
+)
+
+func init() {
+	register(mongoadapter.Migration{
+		Version: %%q,
+		Up: func(ctx context.Context, database *mongo.Database) error {
+			return nil
+		},
+		Down: func(ctx context.Context, database *mongo.Database) error {
+			return nil
+		},
+	})
+}
+`+"`"+`
+`, moduleName)
+	if db.Sql() {
+		imports = append(imports, `"database/sql"`)
+		adapter = "sqladapter"
+		newAdapter = `sqladapter.New(env.Get("DB_URI"), logger)`
+		client = `database := dbAdapter.GetClient().(*sql.DB)`
+		source = "database, migrations.FS"
+		files = `map[string]string{
+		version + ".up.sql":   fmt.Sprintf("-- %s: appliquée par go run ./cmd/migrate up ou au démarrage de l'api\n", version),
+		version + ".down.sql": fmt.Sprintf("-- %s: annule %s.up.sql, appliquée par go run ./cmd/migrate down\n", version, version),
+	}`
+		templates = ""
+	} else {
+		imports = append(imports, `"go.mongodb.org/mongo-driver/mongo"`)
+	}
+	imports = append(imports, fmt.Sprintf("%q", moduleName+"/internal/infrastructure/adapter/"+adapter))
+
+	return fmt.Sprintf(`package main
+
+%[1]s
+
+// migrationsDir dossier des migrations, relatif au dossier de l'api
+const migrationsDir = "migrations"
+
+// migrationName nom d'une migration créée: minuscules, chiffres et _
+var migrationName = regexp.MustCompile(`+"`^[a-z][a-z0-9_]*$`"+`)
+%[7]s
+const usage = `+"`"+`usage: go run ./cmd/migrate <commande>
+  up            applique les migrations en attente
+  down [n]      annule les n dernières migrations appliquées (1 par défaut)
+  status        liste les migrations appliquées et en attente
+  create <nom>  crée une migration vide dans migrations/, ex: create add_products_slug
+`+"`"+`
+
+// migrate applique, annule, liste ou crée les migrations de l'api (dans le conteneur api: make migrate-up, ...)
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+	logger := loggeradapter.New(env.Get("APP_ENV"))
+
+	if err := run(context.Background(), os.Args[1], os.Args[2:], logger); err != nil {
+		logger.Ef("Migrations : %%v", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, command string, args []string, logger loggateway.Logger) error {
+	switch command {
+	case "create":
+		if len(args) != 1 {
+			return fmt.Errorf("nom de la migration absent, ex: create add_products_slug")
+		}
+		return create(args[0])
+	case "up", "down", "status":
+	default:
+		fmt.Print(usage)
+		return fmt.Errorf("commande inconnue %%q", command)
+	}
+
+	dbAdapter := %[3]s
+	if err := dbAdapter.Connect(ctx); err != nil {
+		return err
+	}
+	defer dbAdapter.Disconnect(ctx)
+	%[4]s
+
+	switch command {
+	case "up":
+		return %[2]s.Migrate(ctx, %[5]s, logger)
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 1 {
+				return fmt.Errorf("nombre de migrations à annuler invalide %%q", args[0])
+			}
+			steps = n
+		}
+		return %[2]s.Rollback(ctx, %[5]s, steps, logger)
+	}
+
+	states, err := %[2]s.MigrationStatus(ctx, %[5]s)
+	if err != nil {
+		return err
+	}
+	if len(states) == 0 {
+		fmt.Println("Aucune migration")
+	}
+	for _, state := range states {
+		if state.AppliedAt == nil {
+			fmt.Printf("%%-50s en attente\n", state.Version)
+		} else {
+			fmt.Printf("%%-50s appliquée le %%s\n", state.Version, state.AppliedAt.Format(time.DateTime))
+		}
+	}
+	return nil
+}
+
+// create crée une migration vide <numéro>_<nom>, numérotée après la dernière migration du dossier
+func create(name string) error {
+	// un fichier Go finissant par _test est un fichier de test, ignoré par le build
+	if !migrationName.MatchString(name) || strings.HasSuffix(name, "_test") {
+		return fmt.Errorf("nom de migration invalide %%q: minuscules, chiffres et _ attendus, ex: add_products_slug", name)
+	}
+
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return fmt.Errorf("lecture de %%s (commande à lancer dans le dossier de l'api): %%w", migrationsDir, err)
+	}
+	last := 0
+	for _, entry := range entries {
+		prefix, _, _ := strings.Cut(entry.Name(), "_")
+		if number, err := strconv.Atoi(prefix); err == nil {
+			last = max(last, number)
+		}
+	}
+
+	version := fmt.Sprintf("%%04d_%%s", last+1, name)
+	for file, content := range %[6]s {
+		path := filepath.Join(migrationsDir, file)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("création de %%s: %%w", path, err)
+		}
+		fmt.Printf("Migration créée : %%s\n", path)
+	}
+	return nil
+}
+`, importBlock(imports), adapter, newAdapter, client, source, files, templates)
+}
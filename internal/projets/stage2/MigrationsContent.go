@@ -0,0 +1,56 @@
+package stage2
+
+// MigrationsGoContent package migrations, les fichiers SQL sont embarqués dans le binaire de l'api
+func MigrationsGoContent() string {
+	return `package migrations
+
+import "embed"
+
+// FS migrations SQL de l'api: <version>_<nom>.up.sql appliquée au démarrage (MIGRATE_ON_START) ou par go run ./cmd/migrate up,
+// <version>_<nom>.down.sql pour l'annuler avec go run ./cmd/migrate down, nouvelle migration avec go run ./cmd/migrate create <nom>
+//
+//go:embed *.sql
+var FS embed.FS
+`
+}
+
+// NsevenMigrationUpContent création de la table nsevens
+func NsevenMigrationUpContent(db Db) string {
+	id := "id BIGSERIAL PRIMARY KEY"
+	if db == DbSqlite {
+		id = "id INTEGER PRIMARY KEY AUTOINCREMENT"
+	}
+	return `CREATE TABLE IF NOT EXISTS nsevens (
+    ` + id + `,
+    message TEXT NOT NULL
+);
+`
+}
+
+// NsevenMigrationDownContent suppression de la table nsevens
+func NsevenMigrationDownContent() string {
+	return `DROP TABLE IF EXISTS nsevens;
+`
+}
+
+// UserMigrationUpContent création de la table users (--auth), email unique, rôles séparés par des virgules
+func UserMigrationUpContent(db Db) string {
+	id, createdAt := "id BIGSERIAL PRIMARY KEY", "created_at TIMESTAMPTZ NOT NULL"
+	if db == DbSqlite {
+		id, createdAt = "id INTEGER PRIMARY KEY AUTOINCREMENT", "created_at TIMESTAMP NOT NULL"
+	}
+	return `CREATE TABLE IF NOT EXISTS users (
+    ` + id + `,
+    email TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    roles TEXT NOT NULL DEFAULT 'user',
+    ` + createdAt + `
+);
+`
+}
+
+// UserMigrationDownContent suppression de la table users
+func UserMigrationDownContent() string {
+	return `DROP TABLE IF EXISTS users;
+`
+}
@@ -0,0 +1,228 @@
+package stage2
+
+import "fmt"
+
+// MongoBaseRepositoryContent repository MongoDB générique embarqué par les repositories des entités
+func MongoBaseRepositoryContent(moduleName string) string {
+	return fmt.Sprintf(`package mongoadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"%s/internal/domain/pagination"
+	"time"
+)
+
+// Config configuration d'un Repository
+type Config[T any] struct {
+	// ID retourne l'ID d'une entité, curseur de la pagination
+	ID func(entity *T) string
+	// NotFound erreur retournée quand le document n'existe pas ou est supprimé, ex: product.ErrNotFound
+	NotFound error
+	// AlreadyExists erreur qui enveloppe les violations d'index unique, ex: product.ErrAlreadyExists
+	AlreadyExists error
+	// SoftDelete Delete renseigne deletedAt au lieu de supprimer le document, les lectures ignorent les documents supprimés
+	SoftDelete bool
+}
+
+// Repository opérations communes des repositories MongoDB, à embarquer dans le repository d'une entité:
+//
+//	type mongoProductRepository struct {
+//		*mongoadapter.Repository[product.Product]
+//	}
+//
+// les documents portent createdAt et updatedAt, mis à jour par Create et UpdateByID,
+// l'entité les lit en déclarant les champs (bson "createdAt" et "updatedAt")
+type Repository[T any] struct {
+	collection *mongo.Collection
+	config     Config[T]
+}
+
+// NewRepository repository des entités T de collection
+func NewRepository[T any](collection *mongo.Collection, config Config[T]) *Repository[T] {
+	return &Repository[T]{collection: collection, config: config}
+}
+
+// Collection collection MongoDB du repository, pour les requêtes propres à l'entité
+func (r *Repository[T]) Collection() *mongo.Collection {
+	return r.collection
+}
+
+// FindByID récupère une entité par son ID, Config.NotFound si elle n'existe pas
+func (r *Repository[T]) FindByID(ctx context.Context, id string) (*T, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		// un ID invalide ne peut correspondre à aucun document
+		return nil, r.config.NotFound
+	}
+	return r.FindOne(ctx, bson.M{"_id": objectID})
+}
+
+// FindOne récupère la première entité correspondant à filter, Config.NotFound si aucune ne correspond
+func (r *Repository[T]) FindOne(ctx context.Context, filter bson.M) (*T, error) {
+	var result T
+	err := r.collection.FindOne(ctx, r.filter(filter)).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.config.NotFound
+		}
+		return nil, fmt.Errorf("erreur lors de la récupération: %%w", err)
+	}
+
+	return &result, nil
+}
+
+// FindAll récupère une page d'entités selon la pagination, le tri et les filtres de query
+func (r *Repository[T]) FindAll(ctx context.Context, query pagination.Query) (pagination.Result[*T], error) {
+	return FindPage(ctx, r.collection, r.filter(bson.M{}), query, r.config.ID)
+}
+
+// Count nombre d'entités correspondant à filter
+func (r *Repository[T]) Count(ctx context.Context, filter bson.M) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.filter(filter))
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors du comptage: %%w", err)
+	}
+	return count, nil
+}
+
+// Exists indique si une entité correspond à filter
+func (r *Repository[T]) Exists(ctx context.Context, filter bson.M) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, r.filter(filter), options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("erreur lors de la vérification: %%w", err)
+	}
+	return count > 0, nil
+}
+
+// Create insère une entité avec createdAt et updatedAt, puis y reporte l'ID et les dates du document
+func (r *Repository[T]) Create(ctx context.Context, entity *T) error {
+	document, err := toDocument(entity)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	document["createdAt"] = now
+	document["updatedAt"] = now
+	delete(document, "deletedAt")
+
+	result, err := r.collection.InsertOne(ctx, document)
+	if err != nil {
+		return r.writeError("erreur lors de la création", err)
+	}
+	document["_id"] = result.InsertedID
+
+	return fromDocument(document, entity)
+}
+
+// UpdateByID modifie les champs fields d'une entité et updatedAt, retourne l'entité modifiée,
+// Config.NotFound si elle n'existe pas
+func (r *Repository[T]) UpdateByID(ctx context.Context, id string, fields bson.M) (*T, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, r.config.NotFound
+	}
+
+	set := bson.M{"updatedAt": time.Now().UTC()}
+	for field, value := range fields {
+		set[field] = value
+	}
+
+	var result T
+	err = r.collection.FindOneAndUpdate(
+		ctx,
+		r.filter(bson.M{"_id": objectID}),
+		bson.M{"$set": set},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, r.config.NotFound
+		}
+		return nil, r.writeError("erreur lors de la mise à jour", err)
+	}
+
+	return &result, nil
+}
+
+// Delete supprime une entité, ou renseigne deletedAt avec Config.SoftDelete, Config.NotFound si elle n'existe pas
+func (r *Repository[T]) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return r.config.NotFound
+	}
+
+	var affected int64
+	if r.config.SoftDelete {
+		now := time.Now().UTC()
+		result, err := r.collection.UpdateOne(ctx, r.filter(bson.M{"_id": objectID}), bson.M{"$set": bson.M{"deletedAt": now, "updatedAt": now}})
+		if err != nil {
+			return fmt.Errorf("erreur lors de la suppression: %%w", err)
+		}
+		affected = result.MatchedCount
+	} else {
+		result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+		if err != nil {
+			return fmt.Errorf("erreur lors de la suppression: %%w", err)
+		}
+		affected = result.DeletedCount
+	}
+
+	if affected == 0 {
+		return r.config.NotFound
+	}
+
+	return nil
+}
+
+// filter ajoute à filter l'exclusion des documents supprimés avec Config.SoftDelete
+func (r *Repository[T]) filter(filter bson.M) bson.M {
+	if !r.config.SoftDelete {
+		return filter
+	}
+	withDeleted := bson.M{"deletedAt": nil}
+	for field, value := range filter {
+		withDeleted[field] = value
+	}
+	return withDeleted
+}
+
+// writeError enveloppe une violation d'index unique dans Config.AlreadyExists
+func (r *Repository[T]) writeError(message string, err error) error {
+	if r.config.AlreadyExists != nil && mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("%%w: %%v", r.config.AlreadyExists, err)
+	}
+	return fmt.Errorf("%%s: %%w", message, err)
+}
+
+// toDocument document bson d'une entité
+func toDocument(entity any) (bson.M, error) {
+	data, err := bson.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'encodage: %%w", err)
+	}
+	document := bson.M{}
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("erreur lors de l'encodage: %%w", err)
+	}
+	return document, nil
+}
+
+// fromDocument reporte un document bson dans une entité
+func fromDocument(document bson.M, entity any) error {
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("erreur lors du décodage: %%w", err)
+	}
+	if err := bson.Unmarshal(data, entity); err != nil {
+		return fmt.Errorf("erreur lors du décodage: %%w", err)
+	}
+	return nil
+}
+`, moduleName)
+}
@@ -0,0 +1,208 @@
+package stage2
+
+import "fmt"
+
+// MongoSchemaContent application au démarrage des index et validateurs déclarés par les entités
+func MongoSchemaContent(moduleName string) string {
+	return fmt.Sprintf(`package mongoadapter
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"%[1]s/internal/application/gateway/loggateway"
+	"%[1]s/internal/domain/schema"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// EnsureSchemas crée les collections, index et validateurs déclarés et met à jour ceux qui ont changé,
+// les index non déclarés sont conservés, chaque création ou modification est écrite dans les logs:
+// relancer sans changement de déclaration ne modifie rien
+func EnsureSchemas(ctx context.Context, database *mongo.Database, logger loggateway.Logger, collections ...schema.Collection) error {
+	changes := 0
+	for _, collection := range collections {
+		changed, err := ensureCollection(ctx, database, logger, collection)
+		if err != nil {
+			return fmt.Errorf("collection %%s: %%w", collection.Name, err)
+		}
+		changes += changed
+
+		changed, err = ensureIndexes(ctx, database.Collection(collection.Name), logger, collection)
+		if err != nil {
+			return fmt.Errorf("collection %%s: %%w", collection.Name, err)
+		}
+		changes += changed
+	}
+
+	if changes == 0 {
+		logger.If("Schéma MongoDB à jour: %%d collection(s) vérifiée(s)", len(collections))
+	} else {
+		logger.If("Schéma MongoDB: %%d création(s) ou modification(s) sur %%d collection(s)", changes, len(collections))
+	}
+	return nil
+}
+
+// ensureCollection crée la collection avec son validateur, ou remplace le validateur s'il a changé
+func ensureCollection(ctx context.Context, database *mongo.Database, logger loggateway.Logger, collection schema.Collection) (int, error) {
+	specifications, err := database.ListCollectionSpecifications(ctx, bson.M{"name": collection.Name})
+	if err != nil {
+		return 0, fmt.Errorf("lecture de la collection: %%w", err)
+	}
+
+	if len(specifications) == 0 {
+		createOptions := options.CreateCollection()
+		if collection.Validator != nil {
+			createOptions.SetValidator(validator(collection))
+		}
+		if err := database.CreateCollection(ctx, collection.Name, createOptions); err != nil {
+			return 0, fmt.Errorf("création de la collection: %%w", err)
+		}
+		logger.If("Schéma MongoDB: collection %%s créée", collection.Name)
+		return 1, nil
+	}
+
+	if collection.Validator == nil {
+		return 0, nil
+	}
+	var current struct {
+		Validator bson.M `+"`bson:\"validator\"`"+`
+	}
+	if specifications[0].Options != nil {
+		if err := bson.Unmarshal(specifications[0].Options, &current); err != nil {
+			return 0, fmt.Errorf("lecture du validateur: %%w", err)
+		}
+	}
+	declared, err := normalize(validator(collection))
+	if err != nil {
+		return 0, fmt.Errorf("encodage du validateur: %%w", err)
+	}
+	if reflect.DeepEqual(current.Validator, declared) {
+		return 0, nil
+	}
+
+	if err := database.RunCommand(ctx, bson.D{{Key: "collMod", Value: collection.Name}, {Key: "validator", Value: declared}}).Err(); err != nil {
+		return 0, fmt.Errorf("modification du validateur: %%w", err)
+	}
+	logger.If("Schéma MongoDB: validateur de %%s modifié", collection.Name)
+	return 1, nil
+}
+
+// ensureIndexes crée les index absents et recrée ceux dont la définition a changé
+func ensureIndexes(ctx context.Context, collection *mongo.Collection, logger loggateway.Logger, declared schema.Collection) (int, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("lecture des index: %%w", err)
+	}
+	var existing []indexSpecification
+	if err := cursor.All(ctx, &existing); err != nil {
+		return 0, fmt.Errorf("lecture des index: %%w", err)
+	}
+
+	changes := 0
+	for _, index := range declared.Indexes {
+		name := index.IndexName()
+		position := slices.IndexFunc(existing, func(e indexSpecification) bool { return e.Name == name })
+		if position >= 0 && existing[position].matches(index) {
+			continue
+		}
+
+		action := "créé"
+		if position >= 0 {
+			action = "modifié"
+			if _, err := collection.Indexes().DropOne(ctx, name); err != nil {
+				return changes, fmt.Errorf("suppression de l'index %%s: %%w", name, err)
+			}
+		}
+		if _, err := collection.Indexes().CreateOne(ctx, indexModel(index)); err != nil {
+			return changes, fmt.Errorf("création de l'index %%s: %%w", name, err)
+		}
+		logger.If("Schéma MongoDB: index %%s.%%s %%s", declared.Name, name, action)
+		changes++
+	}
+
+	return changes, nil
+}
+
+// indexSpecification index existant, lu depuis listIndexes
+type indexSpecification struct {
+	Name               string `+"`bson:\"name\"`"+`
+	Key                bson.D `+"`bson:\"key\"`"+`
+	Unique             bool   `+"`bson:\"unique\"`"+`
+	ExpireAfterSeconds *int64 `+"`bson:\"expireAfterSeconds\"`"+`
+	Weights            bson.M `+"`bson:\"weights\"`"+`
+}
+
+// matches indique si l'index existant a la définition de index
+func (s indexSpecification) matches(index schema.Index) bool {
+	var ttl int64
+	if s.ExpireAfterSeconds != nil {
+		ttl = *s.ExpireAfterSeconds
+	}
+	if s.Unique != index.Unique || ttl != int64(index.TTL/time.Second) {
+		return false
+	}
+
+	// les clés d'un index texte sont remplacées par _fts et _ftsx, ses champs sont dans weights
+	if textFields := index.TextFields(); len(textFields) > 0 {
+		if len(s.Weights) != len(textFields) {
+			return false
+		}
+		for _, field := range textFields {
+			if _, ok := s.Weights[field]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	if len(s.Key) != len(index.Keys) {
+		return false
+	}
+	for i, key := range index.Keys {
+		if s.Key[i].Key != key.Field || fmt.Sprint(s.Key[i].Value) != fmt.Sprint(key.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexModel définition MongoDB d'un index déclaré
+func indexModel(index schema.Index) mongo.IndexModel {
+	keys := bson.D{}
+	for _, key := range index.Keys {
+		keys = append(keys, bson.E{Key: key.Field, Value: key.Value()})
+	}
+
+	indexOptions := options.Index().SetName(index.IndexName())
+	if index.Unique {
+		indexOptions.SetUnique(true)
+	}
+	if index.TTL > 0 {
+		indexOptions.SetExpireAfterSeconds(int32(index.TTL / time.Second))
+	}
+	return mongo.IndexModel{Keys: keys, Options: indexOptions}
+}
+
+// validator validateur MongoDB de la collection
+func validator(collection schema.Collection) bson.M {
+	return bson.M{"$jsonSchema": collection.Validator}
+}
+
+// normalize document tel que relu depuis MongoDB (types bson), pour le comparer au validateur existant
+func normalize(document bson.M) (bson.M, error) {
+	data, err := bson.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	normalized := bson.M{}
+	if err := bson.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+`, moduleName)
+}
@@ -48,7 +48,7 @@ import "%s/internal/application/gateway/httpgateway"
 func (c *NsevenController) CreateNseven(ctx httpgateway.Context) {
 	nsevenEntity, err := c.useCase.CreateNseven(ctx.Request().Context())
 	if err != nil {
-		ctx.InternalServerError("Erreur lors de la création", err.Error())
+		ctx.Error("Erreur lors de la création", err)
 		return
 	}
 
@@ -60,25 +60,44 @@ func (c *NsevenController) CreateNseven(ctx httpgateway.Context) {
 func NsevenGetAllContent(moduleName string) string {
 	return fmt.Sprintf(`package nsevencontroller
 
-import "%s/internal/application/gateway/httpgateway"
+import (
+	"%[1]s/internal/application/gateway/httpgateway"
+	"%[1]s/internal/domain/nseven"
+	"%[1]s/internal/domain/pagination"
+	"strconv"
+)
 
-// GetAllNseven récupère tous les Nseven
-// @Summary Récupérer tous les Nseven
-// @Description Récupère la liste de tous les Nseven
+// GetAllNseven récupère une page de Nseven
+// @Summary Récupérer les Nseven
+// @Description Récupère une page de Nseven, le nombre total est aussi dans l'en-tête X-Total-Count
 // @Tags Nseven
 // @Accept json
 // @Produce json
-// @Success 200 {array} map[string]string
+// @Param page query int false "Page, à partir de 1"
+// @Param limit query int false "Éléments par page (20 par défaut, 100 maximum)"
+// @Param cursor query string false "Curseur de la page suivante (nextCursor), remplace page"
+// @Param sort query string false "Tri, ex: message ou -message"
+// @Param message query string false "Filtre sur message"
+// @Success 200 {object} pagination.Result[nseven.Nseven]
+// @Header 200 {integer} X-Total-Count "Nombre total de Nseven"
+// @Failure 422 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /nseven [get]
 func (c *NsevenController) GetAllNseven(ctx httpgateway.Context) {
-	nsevens, err := c.useCase.GetAll(ctx.Request().Context())
+	query, err := pagination.Parse(ctx.Request().URL.Query(), nseven.Fields...)
+	if err != nil {
+		ctx.Error("Paramètres de liste invalides", err)
+		return
+	}
+
+	page, err := c.useCase.GetAll(ctx.Request().Context(), query)
 	if err != nil {
-		ctx.InternalServerError("Erreur lors de la récupération", err.Error())
+		ctx.Error("Erreur lors de la récupération", err)
 		return
 	}
 
-	ctx.Success("Récupération de tous les nseven", nsevens)
+	ctx.SetHeader("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	ctx.Success("Récupération des nseven", page)
 }
 `, moduleName)
 }
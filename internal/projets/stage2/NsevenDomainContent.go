@@ -1,7 +1,22 @@
 package stage2
 
-func NsevenEntityContent() string {
-	return `package nseven
+import "fmt"
+
+func NsevenEntityContent(moduleName string) string {
+	return fmt.Sprintf(`package nseven
+
+import (
+	"%[1]s/internal/domain/domainerror"
+	"%[1]s/internal/domain/pagination"
+)
+
+// ErrNotFound retourné par le repository quand le Nseven n'existe pas
+var ErrNotFound = domainerror.NotFound("nseven non trouvé")
+
+// Fields champs triables et filtrables des listes de Nseven
+var Fields = []pagination.Field{
+	pagination.String("message"),
+}
 
 // Nseven représente l'entité métier Nseven
 type Nseven struct {
@@ -20,30 +35,53 @@ func NewNseven(message string) *Nseven {
 func (n *Nseven) GetGreeting() string {
 	return n.Message
 }
-`
+`, moduleName)
 }
 
-func NsevenRepositoryInterfaceContent() string {
-	return `package nseven
+func NsevenRepositoryInterfaceContent(moduleName string) string {
+	return fmt.Sprintf(`package nseven
 
-import "context"
+import (
+	"context"
+	"%s/internal/domain/pagination"
+)
 
 // NsevenRepository définit les opérations de persistance pour l'entité Nseven
 type NsevenRepository interface {
-	// FindByID récupère un Nseven par son ID
+	// FindByID récupère un Nseven par son ID, ErrNotFound s'il n'existe pas
 	FindByID(ctx context.Context, id string) (*Nseven, error)
 
-	// FindAll récupère tous les Nseven
-	FindAll(ctx context.Context) ([]*Nseven, error)
+	// FindAll récupère une page de Nseven selon la pagination, le tri et les filtres de query
+	FindAll(ctx context.Context, query pagination.Query) (pagination.Result[*Nseven], error)
 
 	// Create crée un nouveau Nseven
 	Create(ctx context.Context, nseven *Nseven) error
 
-	// Update met à jour un Nseven existant
+	// Update met à jour un Nseven existant, ErrNotFound s'il n'existe pas
 	Update(ctx context.Context, nseven *Nseven) error
 
-	// Delete supprime un Nseven par son ID
+	// Delete supprime un Nseven par son ID, ErrNotFound s'il n'existe pas
 	Delete(ctx context.Context, id string) error
 }
-`
+`, moduleName)
+}
+
+// NsevenSchemaContent collection MongoDB de Nseven, appliquée au démarrage par mongoadapter.EnsureSchemas
+func NsevenSchemaContent(moduleName string) string {
+	return fmt.Sprintf(`package nseven
+
+import "%s/internal/domain/schema"
+
+// Schema collection nsevens: validateur appliqué au démarrage de l'api
+var Schema = schema.Collection{
+	Name: "nsevens",
+	Validator: schema.JSONSchema{
+		"bsonType": "object",
+		"required": []string{"message"},
+		"properties": map[string]any{
+			"message": map[string]any{"bsonType": "string"},
+		},
+	},
+}
+`, moduleName)
 }
@@ -7,117 +7,174 @@ func NsevenMongoRepositoryContent(moduleName string) string {
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"%s/internal/domain/nseven"
+	"%[1]s/internal/domain/nseven"
+	"%[1]s/internal/infrastructure/adapter/mongoadapter"
 )
 
+// mongoNsevenRepository FindByID, FindAll, Create et Delete viennent de mongoadapter.Repository
 type mongoNsevenRepository struct {
-	collection *mongo.Collection
+	*mongoadapter.Repository[nseven.Nseven]
 }
 
 // NewMongoNsevenRepository crée une nouvelle instance du repository MongoDB pour Nseven
 func NewMongoNsevenRepository(database *mongo.Database) nseven.NsevenRepository {
 	return &mongoNsevenRepository{
-		collection: database.Collection("nsevens"),
+		Repository: mongoadapter.NewRepository(database.Collection(nseven.Schema.Name), mongoadapter.Config[nseven.Nseven]{
+			ID:       func(n *nseven.Nseven) string { return n.ID },
+			NotFound: nseven.ErrNotFound,
+		}),
+	}
+}
+
+// Update met à jour un Nseven existant
+func (r *mongoNsevenRepository) Update(ctx context.Context, nsevenEntity *nseven.Nseven) error {
+	updated, err := r.UpdateByID(ctx, nsevenEntity.ID, bson.M{
+		"message": nsevenEntity.Message,
+	})
+	if err != nil {
+		return err
+	}
+
+	*nsevenEntity = *updated
+	return nil
+}
+`, moduleName)
+}
+
+// NsevenSqlRepositoryContent repository Nseven pour PostgreSQL ou SQLite, table nsevens de la première migration
+func NsevenSqlRepositoryContent(moduleName string, db Db) string {
+	return fmt.Sprintf(`package nsevenrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"%[1]s/internal/domain/nseven"
+	"%[1]s/internal/domain/pagination"
+	"%[1]s/internal/infrastructure/adapter/sqladapter"
+	"strconv"
+)
+
+type sqlNsevenRepository struct {
+	db *sql.DB
+}
+
+// NewSqlNsevenRepository crée une nouvelle instance du repository %[2]s pour Nseven
+func NewSqlNsevenRepository(db *sql.DB) nseven.NsevenRepository {
+	return &sqlNsevenRepository{
+		db: db,
 	}
 }
 
 // FindByID récupère un Nseven par son ID
-func (r *mongoNsevenRepository) FindByID(ctx context.Context, id string) (*nseven.Nseven, error) {
-	objectID, err := primitive.ObjectIDFromHex(id)
+func (r *sqlNsevenRepository) FindByID(ctx context.Context, id string) (*nseven.Nseven, error) {
+	key, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("ID invalide: %%w", err)
+		// un ID invalide ne peut correspondre à aucun Nseven
+		return nil, nseven.ErrNotFound
 	}
 
 	var result nseven.Nseven
-	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&result)
+	err = r.db.QueryRowContext(ctx, "SELECT id, message FROM nsevens WHERE id = %[3]s", key).Scan(&key, &result.Message)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, fmt.Errorf("nseven non trouvé")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nseven.ErrNotFound
 		}
 		return nil, fmt.Errorf("erreur lors de la récupération: %%w", err)
 	}
+	result.ID = strconv.FormatInt(key, 10)
 
 	return &result, nil
 }
 
-// FindAll récupère tous les Nseven
-func (r *mongoNsevenRepository) FindAll(ctx context.Context) ([]*nseven.Nseven, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{})
+// FindAll récupère une page de Nseven selon la pagination, le tri et les filtres de query
+func (r *sqlNsevenRepository) FindAll(ctx context.Context, query pagination.Query) (pagination.Result[*nseven.Nseven], error) {
+	page, err := sqladapter.NewPage("nsevens", "id, message", query)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération: %%w", err)
+		return pagination.Result[*nseven.Nseven]{}, err
+	}
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, page.Count, page.CountArgs...).Scan(&total); err != nil {
+		return pagination.Result[*nseven.Nseven]{}, fmt.Errorf("erreur lors du comptage: %%w", err)
 	}
-	defer cursor.Close(ctx)
 
-	var results []*nseven.Nseven
-	if err = cursor.All(ctx, &results); err != nil {
-		return nil, fmt.Errorf("erreur lors du décodage: %%w", err)
+	rows, err := r.db.QueryContext(ctx, page.Select, page.SelectArgs...)
+	if err != nil {
+		return pagination.Result[*nseven.Nseven]{}, fmt.Errorf("erreur lors de la récupération: %%w", err)
+	}
+	defer rows.Close()
+
+	results := []*nseven.Nseven{}
+	for rows.Next() {
+		var key int64
+		var result nseven.Nseven
+		if err := rows.Scan(&key, &result.Message); err != nil {
+			return pagination.Result[*nseven.Nseven]{}, fmt.Errorf("erreur lors du décodage: %%w", err)
+		}
+		result.ID = strconv.FormatInt(key, 10)
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return pagination.Result[*nseven.Nseven]{}, fmt.Errorf("erreur lors du décodage: %%w", err)
 	}
 
-	return results, nil
+	return pagination.NewResult(results, total, query, func(n *nseven.Nseven) string { return n.ID }), nil
 }
 
 // Create crée un nouveau Nseven
-func (r *mongoNsevenRepository) Create(ctx context.Context, nsevenEntity *nseven.Nseven) error {
-	result, err := r.collection.InsertOne(ctx, nsevenEntity)
+func (r *sqlNsevenRepository) Create(ctx context.Context, nsevenEntity *nseven.Nseven) error {
+	var key int64
+	err := r.db.QueryRowContext(ctx, "INSERT INTO nsevens (message) VALUES (%[3]s) RETURNING id", nsevenEntity.Message).Scan(&key)
 	if err != nil {
 		return fmt.Errorf("erreur lors de la création: %%w", err)
 	}
 
-	// Mettre à jour l'ID de l'entité avec celui généré par MongoDB
-	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
-		nsevenEntity.ID = oid.Hex()
-	}
+	// Mettre à jour l'ID de l'entité avec celui généré par la base
+	nsevenEntity.ID = strconv.FormatInt(key, 10)
 
 	return nil
 }
 
 // Update met à jour un Nseven existant
-func (r *mongoNsevenRepository) Update(ctx context.Context, nsevenEntity *nseven.Nseven) error {
-	objectID, err := primitive.ObjectIDFromHex(nsevenEntity.ID)
+func (r *sqlNsevenRepository) Update(ctx context.Context, nsevenEntity *nseven.Nseven) error {
+	key, err := strconv.ParseInt(nsevenEntity.ID, 10, 64)
 	if err != nil {
-		return fmt.Errorf("ID invalide: %%w", err)
-	}
-
-	update := bson.M{
-		"$set": bson.M{
-			"message": nsevenEntity.Message,
-		},
+		return nseven.ErrNotFound
 	}
 
-	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	result, err := r.db.ExecContext(ctx, "UPDATE nsevens SET message = %[3]s WHERE id = %[4]s", nsevenEntity.Message, key)
 	if err != nil {
 		return fmt.Errorf("erreur lors de la mise à jour: %%w", err)
 	}
 
-	if result.MatchedCount == 0 {
-		return fmt.Errorf("nseven non trouvé")
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return nseven.ErrNotFound
 	}
 
 	return nil
 }
 
 // Delete supprime un Nseven par son ID
-func (r *mongoNsevenRepository) Delete(ctx context.Context, id string) error {
-	objectID, err := primitive.ObjectIDFromHex(id)
+func (r *sqlNsevenRepository) Delete(ctx context.Context, id string) error {
+	key, err := strconv.ParseInt(id, 10, 64)
 	if err != nil {
-		return fmt.Errorf("ID invalide: %%w", err)
+		return nseven.ErrNotFound
 	}
 
-	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	result, err := r.db.ExecContext(ctx, "DELETE FROM nsevens WHERE id = %[3]s", key)
 	if err != nil {
 		return fmt.Errorf("erreur lors de la suppression: %%w", err)
 	}
 
-	if result.DeletedCount == 0 {
-		return fmt.Errorf("nseven non trouvé")
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return nseven.ErrNotFound
 	}
 
 	return nil
 }
-`, moduleName)
+`, moduleName, db.Label(), db.Placeholder(1), db.Placeholder(2))
 }
@@ -7,7 +7,8 @@ func NsevenUseCaseContent(moduleName string) string {
 
 import (
 	"context"
-	"%s/internal/domain/nseven"
+	"%[1]s/internal/domain/nseven"
+	"%[1]s/internal/domain/pagination"
 )
 
 // NsevenUseCase gère la logique métier pour les Nseven
@@ -27,9 +28,9 @@ func (uc *NsevenUseCase) GetByID(ctx context.Context, id string) (*nseven.Nseven
 	return uc.repo.FindByID(ctx, id)
 }
 
-// GetAll récupère tous les Nseven
-func (uc *NsevenUseCase) GetAll(ctx context.Context) ([]*nseven.Nseven, error) {
-	return uc.repo.FindAll(ctx)
+// GetAll récupère une page de Nseven
+func (uc *NsevenUseCase) GetAll(ctx context.Context, query pagination.Query) (pagination.Result[*nseven.Nseven], error) {
+	return uc.repo.FindAll(ctx, query)
 }
 
 // CreateNseven crée un nouveau Nseven avec le message "Bonjour Nseven"
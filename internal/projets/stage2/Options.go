@@ -0,0 +1,93 @@
+package stage2
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Db base de données de l'api go
+type Db string
+
+const (
+	DbMongo    Db = "mongo"
+	DbPostgres Db = "postgres"
+	DbSqlite   Db = "sqlite"
+)
+
+// Dbs bases de données supportées, dans l'ordre d'affichage
+var Dbs = []Db{DbMongo, DbPostgres, DbSqlite}
+
+// ParseDb valide la valeur du flag --db
+func ParseDb(value string) (Db, error) {
+	for _, db := range Dbs {
+		if string(db) == strings.ToLower(value) {
+			return db, nil
+		}
+	}
+	return "", fmt.Errorf("base de données %q inconnue (valeurs possibles: %s)", value, joinDbs())
+}
+
+// Sql indique une base SQL (adapter database/sql, repositories SQL et migrations)
+func (d Db) Sql() bool {
+	return d == DbPostgres || d == DbSqlite
+}
+
+// Label nom affiché de la base de données
+func (d Db) Label() string {
+	switch d {
+	case DbPostgres:
+		return "PostgreSQL"
+	case DbSqlite:
+		return "SQLite"
+	}
+	return "MongoDB"
+}
+
+// Dependency module go du driver de la base de données
+func (d Db) Dependency() string {
+	switch d {
+	case DbPostgres:
+		return "github.com/jackc/pgx/v5"
+	case DbSqlite:
+		return "modernc.org/sqlite"
+	}
+	return "go.mongodb.org/mongo-driver"
+}
+
+// Placeholder paramètre n d'une requête SQL: $n pour PostgreSQL, ? pour SQLite
+func (d Db) Placeholder(n int) string {
+	if d == DbPostgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// Options fonctionnalités choisies à la création du projet stage2
+type Options struct {
+	Db      Db
+	Cache   bool // cache redis (cachegateway + redisadapter, service redis)
+	Mailer  bool // envoi de mails (mailergateway + smtpadapter, service mailpit en dev)
+	Storage bool // stockage de fichiers S3/R2 (storagegateway + s3adapter, service minio en dev)
+	Auth    bool // authentification JWT (domain user, tokengateway + jwtadapter, authmiddleware)
+}
+
+func joinDbs() string {
+	values := make([]string, len(Dbs))
+	for i, db := range Dbs {
+		values[i] = string(db)
+	}
+	return strings.Join(values, ", ")
+}
+
+// importBlock bloc d'import trié par chemin comme gofmt, un import peut être nommé (ex: `swaggerFiles "github.com/swaggo/files"`)
+func importBlock(imports []string) string {
+	path := func(imp string) string {
+		return imp[strings.Index(imp, `"`):]
+	}
+	sorted := append([]string(nil), imports...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return path(sorted[i]) < path(sorted[j])
+	})
+	return "import (\n\t" + strings.Join(sorted, "\n\t") + "\n)"
+}
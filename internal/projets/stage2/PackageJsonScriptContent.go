@@ -1,11 +1,14 @@
 package stage2
 
-func PackageJsonScriptContent() map[string]string {
-	return map[string]string{
-		"dev":     "astro dev --host 0.0.0.0 --port 3000 --poll 2000",
-		"build":   "astro check && astro build",
-		"preview": "astro preview",
-		"astro":   "astro",
-		"check":   "astro check",
+import "github.com/nsevendev/starter/internal/packagejson"
+
+// PackageJsonScriptContent scripts du front, les scripts ajoutés par le CLI Astro sont conservés
+func PackageJsonScriptContent() []packagejson.Entry {
+	return []packagejson.Entry{
+		packagejson.Set("dev", "astro dev --host 0.0.0.0 --port 3000 --poll 2000"),
+		packagejson.Set("build", "astro check && astro build"),
+		packagejson.Keep("preview", "astro preview"),
+		packagejson.Keep("astro", "astro"),
+		packagejson.Set("check", "astro check"),
 	}
 }
@@ -0,0 +1,166 @@
+package stage2
+
+import "fmt"
+
+// MongoPageContent lecture d'une page pagination.Query dans une collection MongoDB
+func MongoPageContent(moduleName string) string {
+	return fmt.Sprintf(`package mongoadapter
+
+import (
+	"context"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"%s/internal/domain/pagination"
+)
+
+// FindPage lit une page de query dans collection: filtre de base, filtres par égalité, tri de query puis par _id,
+// pagination par page (skip) ou par curseur (_id supérieur au curseur), id retourne l'ID d'un élément
+func FindPage[T any](ctx context.Context, collection *mongo.Collection, base bson.M, query pagination.Query, id func(T) string) (pagination.Result[T], error) {
+	filter := bson.M{}
+	for field, value := range base {
+		filter[field] = value
+	}
+	for field, value := range query.Filters {
+		filter[field] = value
+	}
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return pagination.Result[T]{}, fmt.Errorf("erreur lors du comptage: %%w", err)
+	}
+
+	sort := bson.D{}
+	for _, s := range query.Sort {
+		order := 1
+		if s.Desc {
+			order = -1
+		}
+		sort = append(sort, bson.E{Key: s.Field, Value: order})
+	}
+	// _id départage les égalités du tri et ordonne la pagination par curseur
+	sort = append(sort, bson.E{Key: "_id", Value: 1})
+
+	findOptions := options.Find().SetSort(sort).SetLimit(int64(query.Limit) + 1)
+	if query.Cursor != "" {
+		cursorID, err := primitive.ObjectIDFromHex(query.Cursor)
+		if err != nil {
+			return pagination.Result[T]{}, pagination.ErrInvalidCursor
+		}
+		filter["_id"] = bson.M{"$gt": cursorID}
+	} else {
+		findOptions.SetSkip(query.Offset())
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return pagination.Result[T]{}, fmt.Errorf("erreur lors de la récupération: %%w", err)
+	}
+	defer cursor.Close(ctx)
+
+	items := []T{}
+	if err = cursor.All(ctx, &items); err != nil {
+		return pagination.Result[T]{}, fmt.Errorf("erreur lors du décodage: %%w", err)
+	}
+
+	return pagination.NewResult(items, total, query, id), nil
+}
+`, moduleName)
+}
+
+// SqlPageContent requêtes SQL d'une page pagination.Query, placeholders de la base db
+func SqlPageContent(moduleName string, db Db) string {
+	placeholder := `"?"`
+	if db == DbPostgres {
+		placeholder = `"$" + strconv.Itoa(len(p.args)+1)`
+	}
+
+	return fmt.Sprintf(`package sqladapter
+
+import (
+	"%[1]s/internal/domain/pagination"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Page requêtes de lecture d'une page de pagination.Query sur une table avec une colonne id entière,
+// les noms de champs de la query sont validés par pagination.Parse et utilisés comme colonnes (entre guillemets, ex: "createdAt")
+type Page struct {
+	Count      string // compte les lignes correspondant aux filtres
+	CountArgs  []any
+	Select     string // lit la page: filtres, tri de la query puis par id, limite et décalage ou curseur
+	SelectArgs []any
+}
+
+// NewPage requêtes d'une page de query sur table, columns colonnes lues par Select, ex: "id, message",
+// base conditions SQL sans argument appliquées en plus des filtres, ex: `+"`"+`"deletedAt" IS NULL`+"`"+`
+func NewPage(table, columns string, query pagination.Query, base ...string) (Page, error) {
+	p := &pageBuilder{}
+	conditions := slices.Clone(base)
+	for field, value := range query.Filters {
+		conditions = append(conditions, quote(field)+" = "+p.arg(value))
+	}
+	count := "SELECT COUNT(*) FROM " + table + where(conditions)
+	countArgs := slices.Clone(p.args)
+
+	if query.Cursor != "" {
+		cursorID, err := strconv.ParseInt(query.Cursor, 10, 64)
+		if err != nil {
+			return Page{}, pagination.ErrInvalidCursor
+		}
+		conditions = append(conditions, "id > "+p.arg(cursorID))
+	}
+
+	var orders []string
+	for _, s := range query.Sort {
+		order := quote(s.Field) + " ASC"
+		if s.Desc {
+			order = quote(s.Field) + " DESC"
+		}
+		orders = append(orders, order)
+	}
+	// id départage les égalités du tri et ordonne la pagination par curseur
+	orders = append(orders, "id ASC")
+
+	limit := " LIMIT " + p.arg(query.Limit+1)
+	if query.Cursor == "" {
+		limit += " OFFSET " + p.arg(query.Offset())
+	}
+
+	return Page{
+		Count:      count,
+		CountArgs:  countArgs,
+		Select:     "SELECT " + columns + " FROM " + table + where(conditions) + " ORDER BY " + strings.Join(orders, ", ") + limit,
+		SelectArgs: p.args,
+	}, nil
+}
+
+// quote nom de colonne entre guillemets, la casse des champs camelCase est conservée
+func quote(column string) string {
+	return "\"" + column + "\""
+}
+
+// where clause WHERE des conditions, vide sans condition
+func where(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// pageBuilder arguments d'une requête et leurs placeholders
+type pageBuilder struct {
+	args []any
+}
+
+// arg ajoute un argument et retourne son placeholder
+func (p *pageBuilder) arg(value any) string {
+	placeholder := %[2]s
+	p.args = append(p.args, value)
+	return placeholder
+}
+`, moduleName, placeholder)
+}
@@ -0,0 +1,166 @@
+package stage2
+
+import "fmt"
+
+func PaginationContent(moduleName string) string {
+	return fmt.Sprintf(`package pagination
+
+import (
+	"fmt"
+	"%s/internal/domain/domainerror"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLimit nombre d'éléments par page sans paramètre limit
+	DefaultLimit = 20
+	// MaxLimit nombre maximum d'éléments par page
+	MaxLimit = 100
+)
+
+// ErrInvalidCursor curseur qui n'est pas un ID valide
+var ErrInvalidCursor = domainerror.Validation("cursor", "curseur invalide")
+
+// reserved paramètres de pagination, jamais lus comme filtres
+var reserved = []string{"page", "limit", "cursor", "sort"}
+
+// Sort tri sur un champ
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// Query pagination, tri et filtres d'une liste, lus depuis la query string par Parse:
+// ?page=2&limit=20 ou ?cursor=<id>&limit=20, ?sort=-createdAt,name, ?<champ>=<valeur>
+type Query struct {
+	Page    int            // page demandée à partir de 1, ignorée si Cursor est renseigné
+	Limit   int            // nombre d'éléments par page
+	Cursor  string         // ID du dernier élément de la page précédente, les éléments suivent par ID croissant
+	Sort    []Sort         // tri avant l'ID, vide en pagination par curseur
+	Filters map[string]any // égalité champ = valeur, valeur convertie au type du champ
+}
+
+// Offset nombre d'éléments avant la page en pagination par page
+func (q Query) Offset() int64 {
+	return int64(q.Page-1) * int64(q.Limit)
+}
+
+// Result page d'une liste
+type Result[T any] struct {
+	Items      []T    `+"`json:\"items\"`"+`
+	Total      int64  `+"`json:\"total\"`"+`          // éléments correspondant aux filtres, toutes pages confondues
+	Page       int    `+"`json:\"page,omitempty\"`"+` // absent en pagination par curseur
+	Limit      int    `+"`json:\"limit\"`"+`
+	NextCursor string `+"`json:\"nextCursor,omitempty\"`"+` // curseur de la page suivante, absent sur la dernière page ou avec un tri
+}
+
+// NewResult page de query, items est lu avec une limite de query.Limit+1 pour savoir si une page suit
+func NewResult[T any](items []T, total int64, query Query, id func(T) string) Result[T] {
+	result := Result[T]{Items: items, Total: total, Limit: query.Limit}
+	if query.Cursor == "" {
+		result.Page = query.Page
+	}
+	if len(items) > query.Limit {
+		result.Items = items[:query.Limit]
+		// le curseur suit l'ordre des ID, il n'a pas de sens avec un autre tri
+		if len(query.Sort) == 0 {
+			result.NextCursor = id(result.Items[query.Limit-1])
+		}
+	}
+	return result
+}
+
+// Map convertit les éléments d'une page, ex: entités vers réponses du controller
+func Map[T, U any](result Result[T], convert func(T) U) Result[U] {
+	items := make([]U, len(result.Items))
+	for i, item := range result.Items {
+		items[i] = convert(item)
+	}
+	return Result[U]{Items: items, Total: result.Total, Page: result.Page, Limit: result.Limit, NextCursor: result.NextCursor}
+}
+
+// Field champ filtrable et triable d'une liste, Name est le nom du champ en base et dans la query string
+type Field struct {
+	Name    string
+	Convert func(raw string) (any, error)
+}
+
+// String champ texte
+func String(name string) Field {
+	return Field{Name: name, Convert: func(raw string) (any, error) { return raw, nil }}
+}
+
+// Int champ entier
+func Int(name string) Field {
+	return Field{Name: name, Convert: func(raw string) (any, error) { return strconv.ParseInt(raw, 10, 64) }}
+}
+
+// Float champ décimal
+func Float(name string) Field {
+	return Field{Name: name, Convert: func(raw string) (any, error) { return strconv.ParseFloat(raw, 64) }}
+}
+
+// Bool champ booléen
+func Bool(name string) Field {
+	return Field{Name: name, Convert: func(raw string) (any, error) { return strconv.ParseBool(raw) }}
+}
+
+// Time champ date au format RFC 3339
+func Time(name string) Field {
+	return Field{Name: name, Convert: func(raw string) (any, error) { return time.Parse(time.RFC3339, raw) }}
+}
+
+// Parse lit la pagination, le tri et les filtres de values, seuls les champs de fields sont triables et filtrables,
+// erreur domainerror.Validation si un paramètre est invalide
+func Parse(values url.Values, fields ...Field) (Query, error) {
+	query := Query{Page: 1, Limit: DefaultLimit, Cursor: values.Get("cursor"), Filters: map[string]any{}}
+
+	if raw := values.Get("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return Query{}, domainerror.Validation("page", "page invalide, entier à partir de 1 attendu")
+		}
+		query.Page = page
+	}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return Query{}, domainerror.Validation("limit", fmt.Sprintf("limit invalide, entier de 1 à %%d attendu", MaxLimit))
+		}
+		query.Limit = limit
+	}
+
+	if raw := values.Get("sort"); raw != "" {
+		if query.Cursor != "" {
+			return Query{}, domainerror.Validation("sort", "tri impossible en pagination par curseur")
+		}
+		for _, name := range strings.Split(raw, ",") {
+			sort := Sort{Field: strings.TrimPrefix(name, "-"), Desc: strings.HasPrefix(name, "-")}
+			if !slices.ContainsFunc(fields, func(f Field) bool { return f.Name == sort.Field }) {
+				return Query{}, domainerror.Validation("sort", fmt.Sprintf("tri impossible sur le champ %%q", sort.Field))
+			}
+			query.Sort = append(query.Sort, sort)
+		}
+	}
+
+	for _, field := range fields {
+		raw, ok := values[field.Name]
+		if !ok || slices.Contains(reserved, field.Name) {
+			continue
+		}
+		value, err := field.Convert(raw[0])
+		if err != nil {
+			return Query{}, domainerror.Validation(field.Name, fmt.Sprintf("valeur invalide pour le filtre %%s", field.Name))
+		}
+		query.Filters[field.Name] = value
+	}
+
+	return query, nil
+}
+`, moduleName)
+}
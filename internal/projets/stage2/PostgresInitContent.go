@@ -0,0 +1,29 @@
+package stage2
+
+import "fmt"
+
+func PostgresInitContent(nameFolderProject string) string {
+	return fmt.Sprintf(`#!/bin/sh
+# Création des bases si non présentes, à la première création du container database ainsi que le volume associé.
+set -e
+
+create_if_not_exists() {
+  if psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname postgres -tAc "SELECT 1 FROM pg_database WHERE datname = '$1'" | grep -q 1; then
+    echo "ℹ️ La base $1 existe déjà, aucune action effectuée."
+  else
+    psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname postgres -c "CREATE DATABASE \"$1\""
+    echo "✅ La base $1 a été créée."
+  fi
+}
+
+create_if_not_exists "%s_prod"
+create_if_not_exists "%s_preprod"
+create_if_not_exists "%s_dev"
+create_if_not_exists "%s_test"
+`,
+		nameFolderProject,
+		nameFolderProject,
+		nameFolderProject,
+		nameFolderProject,
+	)
+}
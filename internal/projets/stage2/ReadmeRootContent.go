@@ -2,7 +2,15 @@ package stage2
 
 import "fmt"
 
-func ReadmeContent(nameApp string) string {
+func ReadmeContent(nameApp string, opts Options) string {
+	initDb := ""
+	switch opts.Db {
+	case DbMongo:
+		initDb = "docker/mongo-init\n"
+	case DbPostgres:
+		initDb = "docker/postgres-init\n"
+	}
+
 	return fmt.Sprintf(`# %v
 
 ## Prérequis
@@ -32,8 +40,7 @@ preparer sur le server dans le dossier ~/preprod/%v avec le contenu suivant
 .env
 Makefile
 docker/compose.preprod.yaml
-docker/mongo-init
-front/.env
+%vfront/.env
 api/.env
 
 - prod
@@ -41,9 +48,8 @@ preparer sur le server dans le dossier ~/prod/%v avec le contenu suivant
 .env
 Makefile
 docker/compose.prod.yaml
-docker/mongo-init
-front/.env
+%vfront/.env
 api/.env
 
-`, nameApp, nameApp, nameApp)
+`, nameApp, nameApp, initDb, nameApp, initDb)
 }
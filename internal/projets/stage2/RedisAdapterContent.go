@@ -0,0 +1,140 @@
+package stage2
+
+import "fmt"
+
+func RedisAdapterContent(moduleName string) string {
+	return fmt.Sprintf(`package redisadapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"%s/internal/application/gateway/cachegateway"
+	"%s/internal/application/gateway/loggateway"
+	"time"
+)
+
+type redisAdapter struct {
+	client   *redis.Client
+	addr     string
+	password string
+	logger   loggateway.Logger
+}
+
+// New crée une nouvelle instance de l'adaptateur Redis
+func New(addr, password string, logger loggateway.Logger) cachegateway.Cache {
+	return &redisAdapter{
+		addr:     addr,
+		password: password,
+		logger:   logger,
+	}
+}
+
+// Connect établit la connexion à Redis
+func (r *redisAdapter) Connect(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     r.addr,
+		Password: r.password,
+	})
+
+	// Vérifier la connexion
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		r.logger.Ef("Erreur lors du ping Redis: %%v", err)
+		return fmt.Errorf("erreur de ping Redis: %%w", err)
+	}
+
+	r.client = client
+
+	r.logger.If("Connexion à Redis établie avec succès - Adresse: %%s", r.addr)
+
+	return nil
+}
+
+// Disconnect ferme la connexion à Redis
+func (r *redisAdapter) Disconnect(ctx context.Context) error {
+	if r.client == nil {
+		return nil
+	}
+
+	if err := r.client.Close(); err != nil {
+		r.logger.Ef("Erreur lors de la déconnexion de Redis: %%v", err)
+		return fmt.Errorf("erreur de déconnexion Redis: %%w", err)
+	}
+
+	r.logger.If("Déconnexion de Redis réussie")
+	return nil
+}
+
+// Ping vérifie la connexion à Redis
+func (r *redisAdapter) Ping(ctx context.Context) error {
+	if r.client == nil {
+		return fmt.Errorf("client Redis non initialisé")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("erreur de ping Redis: %%w", err)
+	}
+
+	return nil
+}
+
+// Get récupère la valeur d'une clé, cachegateway.ErrMiss si la clé est absente ou expirée
+func (r *redisAdapter) Get(ctx context.Context, key string) ([]byte, error) {
+	if r.client == nil {
+		return nil, fmt.Errorf("client Redis non initialisé")
+	}
+
+	value, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, cachegateway.ErrMiss
+		}
+		return nil, fmt.Errorf("erreur lors de la lecture de %%s: %%w", key, err)
+	}
+
+	return value, nil
+}
+
+// Set enregistre la valeur d'une clé, sans expiration si ttl vaut 0
+func (r *redisAdapter) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if r.client == nil {
+		return fmt.Errorf("client Redis non initialisé")
+	}
+
+	if err := r.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("erreur lors de l'écriture de %%s: %%w", key, err)
+	}
+
+	return nil
+}
+
+// Delete supprime des clés, une clé absente est ignorée
+func (r *redisAdapter) Delete(ctx context.Context, keys ...string) error {
+	if r.client == nil {
+		return fmt.Errorf("client Redis non initialisé")
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("erreur lors de la suppression: %%w", err)
+	}
+
+	return nil
+}
+
+// GetClient retourne le client Redis natif
+func (r *redisAdapter) GetClient() *redis.Client {
+	return r.client
+}
+`, moduleName, moduleName)
+}
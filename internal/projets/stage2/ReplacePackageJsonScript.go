@@ -1,44 +1,15 @@
 package stage2
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
-	"os"
+	"github.com/nsevendev/starter/internal/packagejson"
 )
 
-func ReplacePackageJsonScripts(pathFilePackageJson string, newScripts map[string]string) error {
-	// lire package.json
-	data, err := os.ReadFile(pathFilePackageJson)
+func MergePackageJsonScripts(pathFilePackageJson string, scripts []packagejson.Entry) (*packagejson.Report, error) {
+	// fusion clé par clé, les scripts non listés sont conservés
+	report, err := packagejson.MergeFile(pathFilePackageJson, packagejson.Merge{Scripts: scripts})
 	if err != nil {
-		return fmt.Errorf("lecture %s: %w", pathFilePackageJson, err)
+		return nil, fmt.Errorf("modification des scripts: %w", err)
 	}
-
-	// parser en map générique pour ne toucher qu'à "scripts"
-	var pkg map[string]any
-	if err := json.Unmarshal(data, &pkg); err != nil {
-		return fmt.Errorf("parse JSON: %w", err)
-	}
-
-	// remplacer ENTIEREMENT la clé "scripts"
-	scripts := make(map[string]any, len(newScripts))
-	for k, v := range newScripts {
-		scripts[k] = v
-	}
-	pkg["scripts"] = scripts
-
-	// réécrire le script SANS échapper les caractères HTML
-	buffer := new(bytes.Buffer)
-	encoder := json.NewEncoder(buffer)
-	encoder.SetEscapeHTML(false)
-	encoder.SetIndent("", "  ")
-
-	if err := encoder.Encode(pkg); err != nil {
-		return fmt.Errorf("marshal: %w", err)
-	}
-
-	if err := os.WriteFile(pathFilePackageJson, buffer.Bytes(), 0o644); err != nil {
-		return fmt.Errorf("écriture %s: %w", pathFilePackageJson, err)
-	}
-	return nil
+	return report, nil
 }
@@ -0,0 +1,186 @@
+package stage2
+
+import "fmt"
+
+func S3AdapterContent(moduleName string) string {
+	return fmt.Sprintf(`package s3adapter
+
+import (
+	"context"
+	"fmt"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
+	"%[1]s/internal/application/gateway/loggateway"
+	"%[1]s/internal/application/gateway/storagegateway"
+	"time"
+)
+
+// region R2 accepte us-east-1 comme alias de auto, c'est aussi la région par défaut de MinIO
+const region = "us-east-1"
+
+// Config connexion au stockage S3 (R2_* du .env)
+type Config struct {
+	// Endpoint host:port de l'api S3, <AccountID>.r2.cloudflarestorage.com si vide
+	Endpoint string
+	// PublicEndpoint host des urls présignées (https) quand Endpoint n'est pas joignable par le navigateur
+	// (minio:9000 en dev), Endpoint si vide
+	PublicEndpoint  string
+	AccountID       string
+	AccessKeyID     string
+	SecretAccessKey string
+	Bucket          string
+	UseSSL          bool
+}
+
+type s3Adapter struct {
+	client  *minio.Client
+	presign *minio.Client
+	config  Config
+	logger  loggateway.Logger
+}
+
+// New crée une nouvelle instance de l'adaptateur S3, compatible Cloudflare R2 et MinIO
+func New(config Config, logger loggateway.Logger) storagegateway.Storage {
+	if config.Endpoint == "" {
+		config.Endpoint = config.AccountID + ".r2.cloudflarestorage.com"
+	}
+	return &s3Adapter{
+		config: config,
+		logger: logger,
+	}
+}
+
+// Connect vérifie l'accès au bucket et le crée s'il n'existe pas (dev)
+func (s *s3Adapter) Connect(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	client, err := s.newClient(s.config.Endpoint, s.config.UseSSL)
+	if err != nil {
+		return fmt.Errorf("erreur de création du client S3: %%w", err)
+	}
+	presign := client
+	if s.config.PublicEndpoint != "" {
+		// la région est fixée: les urls sont signées sans appel au serveur public
+		if presign, err = s.newClient(s.config.PublicEndpoint, true); err != nil {
+			return fmt.Errorf("erreur de création du client S3 public: %%w", err)
+		}
+	}
+
+	exists, err := client.BucketExists(ctx, s.config.Bucket)
+	if err != nil {
+		s.logger.Ef("Erreur lors de l'accès au bucket %%s: %%v", s.config.Bucket, err)
+		return fmt.Errorf("erreur d'accès au bucket %%s: %%w", s.config.Bucket, err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, s.config.Bucket, minio.MakeBucketOptions{Region: region}); err != nil {
+			return fmt.Errorf("erreur de création du bucket %%s: %%w", s.config.Bucket, err)
+		}
+		s.logger.If("Bucket %%s créé", s.config.Bucket)
+	}
+
+	s.client = client
+	s.presign = presign
+
+	s.logger.If("Connexion au stockage S3 établie avec succès - Endpoint: %%s, Bucket: %%s", s.config.Endpoint, s.config.Bucket)
+
+	return nil
+}
+
+// Ping vérifie l'accès au bucket
+func (s *s3Adapter) Ping(ctx context.Context) error {
+	if s.client == nil {
+		return fmt.Errorf("client S3 non initialisé")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	exists, err := s.client.BucketExists(ctx, s.config.Bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %%s introuvable", s.config.Bucket)
+	}
+	return nil
+}
+
+// Put enregistre un fichier, size vaut -1 si la taille est inconnue
+func (s *s3Adapter) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if _, err := s.client.PutObject(ctx, s.config.Bucket, key, body, size, minio.PutObjectOptions{ContentType: contentType}); err != nil {
+		s.logger.Ef("Erreur lors de l'envoi du fichier %%s: %%v", key, err)
+		return fmt.Errorf("erreur d'envoi du fichier %%s: %%w", key, err)
+	}
+	return nil
+}
+
+// Get lit un fichier, storagegateway.ErrNotFound si le fichier est absent
+func (s *s3Adapter) Get(ctx context.Context, key string) (*storagegateway.Object, error) {
+	object, err := s.client.GetObject(ctx, s.config.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("erreur de lecture du fichier %%s: %%w", key, err)
+	}
+	// GetObject ne contacte le serveur qu'à la première lecture: Stat vérifie que le fichier existe
+	info, err := object.Stat()
+	if err != nil {
+		_ = object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, storagegateway.ErrNotFound
+		}
+		return nil, fmt.Errorf("erreur de lecture du fichier %%s: %%w", key, err)
+	}
+
+	return &storagegateway.Object{
+		Key:         key,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		Body:        object,
+	}, nil
+}
+
+// Delete supprime un fichier, un fichier absent est ignoré
+func (s *s3Adapter) Delete(ctx context.Context, key string) error {
+	if err := s.client.RemoveObject(ctx, s.config.Bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		s.logger.Ef("Erreur lors de la suppression du fichier %%s: %%v", key, err)
+		return fmt.Errorf("erreur de suppression du fichier %%s: %%w", key, err)
+	}
+	return nil
+}
+
+// PresignGet url temporaire de téléchargement d'un fichier, valable ttl
+func (s *s3Adapter) PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	u, err := s.presign.PresignedGetObject(ctx, s.config.Bucket, key, ttl, nil)
+	if err != nil {
+		return "", fmt.Errorf("erreur de signature de l'url du fichier %%s: %%w", key, err)
+	}
+	return u.String(), nil
+}
+
+// PresignPut url temporaire d'envoi d'un fichier (PUT) directement depuis le front, valable ttl
+func (s *s3Adapter) PresignPut(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	u, err := s.presign.PresignedPutObject(ctx, s.config.Bucket, key, ttl)
+	if err != nil {
+		return "", fmt.Errorf("erreur de signature de l'url d'envoi du fichier %%s: %%w", key, err)
+	}
+	return u.String(), nil
+}
+
+// GetClient retourne le client minio (api S3) pour les opérations non couvertes par le gateway
+func (s *s3Adapter) GetClient() *minio.Client {
+	return s.client
+}
+
+func (s *s3Adapter) newClient(endpoint string, secure bool) (*minio.Client, error) {
+	return minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(s.config.AccessKeyID, s.config.SecretAccessKey, ""),
+		Secure: secure,
+		Region: region,
+	})
+}
+`, moduleName)
+}
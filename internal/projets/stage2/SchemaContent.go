@@ -0,0 +1,88 @@
+package stage2
+
+// SchemaContent déclaration des index et du validateur d'une collection par une entité
+func SchemaContent() string {
+	return `package schema
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Collection index et validateur d'une collection, déclarés par une entité (var Schema du package de l'entité)
+// et appliqués au démarrage de l'api par mongoadapter.EnsureSchemas
+type Collection struct {
+	Name      string
+	Indexes   []Index
+	Validator JSONSchema // $jsonSchema des documents, nil sans validateur
+}
+
+// JSONSchema validateur $jsonSchema, ex: {"bsonType": "object", "required": []string{"email"}, "properties": ...}
+type JSONSchema map[string]any
+
+// Index index d'une collection: simple, composé (plusieurs clés), unique, TTL ou texte
+type Index struct {
+	Name   string // nom de l'index, généré depuis les clés si vide, ex: "status_1_createdAt_-1"
+	Keys   []Key
+	Unique bool          // une seule valeur par document
+	TTL    time.Duration // supprime les documents TTL après la date du champ, index d'un seul champ date
+}
+
+// Key champ d'un index
+type Key struct {
+	Field string
+	Desc  bool // ordre décroissant
+	Text  bool // recherche texte ($text)
+}
+
+// Asc clé en ordre croissant
+func Asc(field string) Key {
+	return Key{Field: field}
+}
+
+// Desc clé en ordre décroissant
+func Desc(field string) Key {
+	return Key{Field: field, Desc: true}
+}
+
+// Text clé d'un index texte
+func Text(field string) Key {
+	return Key{Field: field, Text: true}
+}
+
+// Value valeur de la clé dans la définition de l'index: 1, -1 ou "text"
+func (k Key) Value() any {
+	switch {
+	case k.Text:
+		return "text"
+	case k.Desc:
+		return -1
+	}
+	return 1
+}
+
+// IndexName nom de l'index, Name ou le nom donné par MongoDB à un index sans nom, ex: "email_1"
+func (i Index) IndexName() string {
+	if i.Name != "" {
+		return i.Name
+	}
+	parts := make([]string, len(i.Keys))
+	for j, key := range i.Keys {
+		parts[j] = fmt.Sprintf("%s_%v", key.Field, key.Value())
+	}
+	return strings.Join(parts, "_")
+}
+
+// TextFields champs de recherche texte de l'index, vide pour un index qui n'est pas texte
+func (i Index) TextFields() []string {
+	var fields []string
+	for _, key := range i.Keys {
+		if key.Text {
+			fields = append(fields, key.Field)
+		}
+	}
+	return fields
+}
+`
+}
@@ -0,0 +1,353 @@
+package stage2
+
+import "fmt"
+
+func SmtpAdapterContent(moduleName string) string {
+	return fmt.Sprintf(`package smtpadapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"%[1]s/internal/application/gateway/loggateway"
+	"%[1]s/internal/application/gateway/mailergateway"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Config connexion au serveur SMTP (MAIL_HOST, MAIL_PORT, MAIL_USER, MAIL_PASS, MAIL_FROM)
+type Config struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	From     string
+}
+
+type smtpAdapter struct {
+	config    Config
+	templates fs.FS
+	logger    loggateway.Logger
+}
+
+// part partie MIME d'un mail
+type part struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// New crée une nouvelle instance de l'adaptateur SMTP, templates contient les templates <nom>.html et <nom>.txt
+func New(config Config, templates fs.FS, logger loggateway.Logger) mailergateway.Mailer {
+	return &smtpAdapter{
+		config:    config,
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+// Send envoie un mail, en HTML et/ou en texte, avec ses pièces jointes
+func (s *smtpAdapter) Send(ctx context.Context, message mailergateway.Message) error {
+	if len(message.To) == 0 {
+		return fmt.Errorf("mail sans destinataire")
+	}
+
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("expéditeur %%q invalide: %%w", s.config.From, err)
+	}
+
+	if message.Template != "" {
+		message.HTML, message.Text, err = s.render(message.Template, message.Data)
+		if err != nil {
+			return err
+		}
+	}
+	if message.HTML == "" && message.Text == "" {
+		return fmt.Errorf("mail sans contenu")
+	}
+
+	content, recipients, err := buildMessage(from, message)
+	if err != nil {
+		return err
+	}
+
+	if err := s.send(ctx, from.Address, recipients, content); err != nil {
+		s.logger.Ef("Erreur lors de l'envoi du mail %%q: %%v", message.Subject, err)
+		return fmt.Errorf("erreur d'envoi du mail: %%w", err)
+	}
+
+	s.logger.If("Mail %%q envoyé à %%s", message.Subject, strings.Join(message.To, ", "))
+	return nil
+}
+
+// render rend les templates <name>.html et <name>.txt, au moins un des deux doit exister
+func (s *smtpAdapter) render(name string, data any) (string, string, error) {
+	var html, text bytes.Buffer
+	found := false
+
+	if content, err := fs.ReadFile(s.templates, name+".html"); err == nil {
+		found = true
+		t, err := htmltemplate.New(name).Parse(string(content))
+		if err != nil {
+			return "", "", fmt.Errorf("template %%s.html: %%w", name, err)
+		}
+		if err := t.Execute(&html, data); err != nil {
+			return "", "", fmt.Errorf("template %%s.html: %%w", name, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", "", fmt.Errorf("lecture du template %%s.html: %%w", name, err)
+	}
+
+	if content, err := fs.ReadFile(s.templates, name+".txt"); err == nil {
+		found = true
+		t, err := texttemplate.New(name).Parse(string(content))
+		if err != nil {
+			return "", "", fmt.Errorf("template %%s.txt: %%w", name, err)
+		}
+		if err := t.Execute(&text, data); err != nil {
+			return "", "", fmt.Errorf("template %%s.txt: %%w", name, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", "", fmt.Errorf("lecture du template %%s.txt: %%w", name, err)
+	}
+
+	if !found {
+		return "", "", fmt.Errorf("template %%s introuvable (%%s.html ou %%s.txt)", name, name, name)
+	}
+	return html.String(), text.String(), nil
+}
+
+// Ping vérifie que le serveur SMTP répond, sans authentification ni envoi
+func (s *smtpAdapter) Ping(ctx context.Context) error {
+	client, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Noop(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// connect ouvre la connexion au serveur SMTP, TLS direct sur le port 465
+func (s *smtpAdapter) connect(ctx context.Context) (*smtp.Client, error) {
+	dialer := net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.config.Host, s.config.Port))
+	if err != nil {
+		return nil, err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(30 * time.Second)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if s.config.Port == "465" {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.config.Host})
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// send envoie le mail au serveur SMTP: STARTTLS si le serveur le propose,
+// authentification si MAIL_USER est renseigné
+func (s *smtpAdapter) send(ctx context.Context, from string, recipients []string, content []byte) error {
+	client, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return err
+		}
+	}
+	if s.config.User != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.config.User, s.config.Password, s.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(content); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// buildMessage construit le mail MIME et la liste des destinataires (To, Cc et Bcc)
+func buildMessage(from *mail.Address, message mailergateway.Message) ([]byte, []string, error) {
+	header := textproto.MIMEHeader{}
+	header.Set("From", from.String())
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", fmt.Sprintf("<%%d@%%s>", time.Now().UnixNano(), from.Address[strings.LastIndex(from.Address, "@")+1:]))
+	header.Set("MIME-Version", "1.0")
+
+	var recipients []string
+	for _, field := range []struct {
+		name      string
+		addresses []string
+	}{
+		{"To", message.To},
+		{"Cc", message.Cc},
+		{"Bcc", message.Bcc},
+	} {
+		var values []string
+		for _, address := range field.addresses {
+			parsed, err := mail.ParseAddress(address)
+			if err != nil {
+				return nil, nil, fmt.Errorf("destinataire %%q invalide: %%w", address, err)
+			}
+			recipients = append(recipients, parsed.Address)
+			values = append(values, parsed.String())
+		}
+		// Bcc n'apparaît pas dans les en-têtes
+		if len(values) > 0 && field.name != "Bcc" {
+			header.Set(field.name, strings.Join(values, ", "))
+		}
+	}
+	if message.ReplyTo != "" {
+		replyTo, err := mail.ParseAddress(message.ReplyTo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("adresse de réponse %%q invalide: %%w", message.ReplyTo, err)
+		}
+		header.Set("Reply-To", replyTo.String())
+	}
+
+	var alternatives []part
+	if message.Text != "" {
+		alternatives = append(alternatives, textPart("text/plain", message.Text))
+	}
+	if message.HTML != "" {
+		alternatives = append(alternatives, textPart("text/html", message.HTML))
+	}
+	body := alternatives[0]
+	if len(alternatives) > 1 {
+		body = multipartPart("alternative", alternatives)
+	}
+	if len(message.Attachments) > 0 {
+		parts := []part{body}
+		for _, attachment := range message.Attachments {
+			parts = append(parts, attachmentPart(attachment))
+		}
+		body = multipartPart("mixed", parts)
+	}
+	for key, values := range body.header {
+		header[key] = values
+	}
+
+	var content bytes.Buffer
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&content, "%%s: %%s\r\n", key, header.Get(key))
+	}
+	content.WriteString("\r\n")
+	content.Write(body.body)
+	return content.Bytes(), recipients, nil
+}
+
+// textPart partie texte en UTF-8 encodée en quoted-printable
+func textPart(contentType, text string) part {
+	var body bytes.Buffer
+	w := quotedprintable.NewWriter(&body)
+	_, _ = w.Write([]byte(text))
+	_ = w.Close()
+	return part{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType + "; charset=utf-8"},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		body: body.Bytes(),
+	}
+}
+
+// multipartPart regroupe des parties dans une partie multipart/<subtype>
+func multipartPart(subtype string, parts []part) part {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, p := range parts {
+		pw, _ := w.CreatePart(p.header)
+		_, _ = pw.Write(p.body)
+	}
+	_ = w.Close()
+	return part{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": w.Boundary()})},
+		},
+		body: body.Bytes(),
+	}
+}
+
+// attachmentPart pièce jointe encodée en base64, en lignes de 76 caractères
+func attachmentPart(attachment mailergateway.Attachment) part {
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(attachment.Filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+	var body bytes.Buffer
+	for len(encoded) > 76 {
+		body.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	body.WriteString(encoded + "\r\n")
+
+	return part{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		body: body.Bytes(),
+	}
+}
+`, moduleName)
+}
@@ -0,0 +1,102 @@
+package stage2
+
+import "fmt"
+
+// SqlAdapterContent adapter database/sql avec le driver de la base (pgx pour PostgreSQL, modernc pour SQLite sans cgo)
+func SqlAdapterContent(moduleName string, db Db) string {
+	driverImport, driverName := `_ "github.com/jackc/pgx/v5/stdlib"`, "pgx"
+	if db == DbSqlite {
+		driverImport, driverName = `_ "modernc.org/sqlite"`, "sqlite"
+	}
+
+	return fmt.Sprintf(`package sqladapter
+
+%[2]s
+
+// driverName driver database/sql de la base %[3]s
+const driverName = "%[4]s"
+
+type sqlAdapter struct {
+	db     *sql.DB
+	dsn    string
+	logger loggateway.Logger
+}
+
+// New crée une nouvelle instance de l'adaptateur %[3]s
+func New(dsn string, logger loggateway.Logger) dbgateway.Database {
+	return &sqlAdapter{
+		dsn:    dsn,
+		logger: logger,
+	}
+}
+
+// Connect établit la connexion à %[3]s
+func (s *sqlAdapter) Connect(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	db, err := sql.Open(driverName, s.dsn)
+	if err != nil {
+		s.logger.Ef("Erreur lors de la connexion à %[3]s: %%v", err)
+		return fmt.Errorf("erreur de connexion %[3]s: %%w", err)
+	}
+
+	// Vérifier la connexion
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		s.logger.Ef("Erreur lors du ping %[3]s: %%v", err)
+		return fmt.Errorf("erreur de ping %[3]s: %%w", err)
+	}
+
+	s.db = db
+
+	s.logger.If("Connexion à %[3]s établie avec succès")
+
+	return nil
+}
+
+// Disconnect ferme la connexion à %[3]s
+func (s *sqlAdapter) Disconnect(ctx context.Context) error {
+	if s.db == nil {
+		return nil
+	}
+
+	if err := s.db.Close(); err != nil {
+		s.logger.Ef("Erreur lors de la déconnexion de %[3]s: %%v", err)
+		return fmt.Errorf("erreur de déconnexion %[3]s: %%w", err)
+	}
+
+	s.logger.If("Déconnexion de %[3]s réussie")
+	return nil
+}
+
+// Ping vérifie la connexion à %[3]s
+func (s *sqlAdapter) Ping(ctx context.Context) error {
+	if s.db == nil {
+		return fmt.Errorf("client %[3]s non initialisé")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("erreur de ping %[3]s: %%w", err)
+	}
+
+	return nil
+}
+
+// GetClient retourne le *sql.DB natif
+func (s *sqlAdapter) GetClient() interface{} {
+	return s.db
+}
+`, moduleName, importBlock([]string{
+		`"context"`,
+		`"database/sql"`,
+		`"fmt"`,
+		driverImport,
+		fmt.Sprintf("%q", moduleName+"/internal/application/gateway/dbgateway"),
+		fmt.Sprintf("%q", moduleName+"/internal/application/gateway/loggateway"),
+		`"time"`,
+	}), db.Label(), driverName)
+}
@@ -0,0 +1,50 @@
+package stage2
+
+import "fmt"
+
+func StorageGatewayContent(moduleName string) string {
+	return fmt.Sprintf(`package storagegateway
+
+import (
+	"context"
+	"io"
+	"%s/internal/domain/domainerror"
+	"time"
+)
+
+// ErrNotFound fichier absent du stockage
+var ErrNotFound = domainerror.NotFound("fichier introuvable")
+
+// Object fichier lu depuis le stockage, Body doit être fermé par l'appelant
+type Object struct {
+	Key         string
+	ContentType string
+	Size        int64
+	Body        io.ReadCloser
+}
+
+// Storage représente le gateway pour le stockage de fichiers (S3, R2, MinIO)
+type Storage interface {
+	// Connect vérifie l'accès au bucket et le crée s'il n'existe pas
+	Connect(ctx context.Context) error
+
+	// Ping vérifie l'accès au bucket
+	Ping(ctx context.Context) error
+
+	// Put enregistre un fichier, size vaut -1 si la taille est inconnue
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+
+	// Get lit un fichier, ErrNotFound si le fichier est absent
+	Get(ctx context.Context, key string) (*Object, error)
+
+	// Delete supprime un fichier, un fichier absent est ignoré
+	Delete(ctx context.Context, key string) error
+
+	// PresignGet url temporaire de téléchargement d'un fichier, valable ttl
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// PresignPut url temporaire d'envoi d'un fichier (PUT) directement depuis le front, valable ttl
+	PresignPut(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+`, moduleName)
+}
@@ -0,0 +1,56 @@
+package stage2
+
+import "fmt"
+
+func TokenGatewayContent(moduleName string) string {
+	return fmt.Sprintf(`package tokengateway
+
+import (
+	"%s/internal/domain/domainerror"
+	"time"
+)
+
+// ErrInvalidToken token mal formé, mal signé, expiré ou d'un autre type que celui attendu
+var ErrInvalidToken = domainerror.Unauthorized("token invalide")
+
+// TokenType type d'un token: access pour les requêtes, refresh pour renouveler les tokens
+type TokenType string
+
+const (
+	Access  TokenType = "access"
+	Refresh TokenType = "refresh"
+)
+
+// Claims informations portées par un token
+type Claims struct {
+	UserID    string
+	Email     string
+	Roles     []string
+	Type      TokenType
+	ExpiresAt time.Time
+}
+
+// Tokens représente le gateway pour la création et la vérification des tokens d'authentification
+type Tokens interface {
+	// Generate crée un token signé valable ttl
+	Generate(claims Claims, ttl time.Duration) (string, error)
+
+	// Parse vérifie un token et retourne ses claims, ErrInvalidToken s'il n'est pas valide ou pas du type attendu
+	Parse(token string, tokenType TokenType) (*Claims, error)
+}
+`, moduleName)
+}
+
+func PasswordGatewayContent() string {
+	return `package passwordgateway
+
+// Hasher représente le gateway pour le hash des mots de passe
+type Hasher interface {
+	// Hash hash un mot de passe, le résultat contient le sel
+	Hash(password string) (string, error)
+
+	// Compare vérifie qu'un mot de passe correspond à son hash
+	Compare(hash, password string) bool
+}
+`
+}
@@ -0,0 +1,148 @@
+package stage2
+
+import "fmt"
+
+func UploadControllerContent(moduleName string) string {
+	return fmt.Sprintf(`package uploadcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+	"%s/internal/application/usecase/uploadusecase"
+)
+
+// UploadedFileResponse fichier retourné par UploadFile
+// déclaré dans le package du controller pour que swag le trouve depuis UploadFile.go
+type UploadedFileResponse = uploadusecase.UploadedFile
+
+// maxFileSize taille maximale d'un fichier envoyé (10 Mo)
+const maxFileSize = 10 << 20
+
+type UploadController struct {
+	useCase   *uploadusecase.UploadUseCase
+	prefixUrl string
+}
+
+// New crée une nouvelle instance du controller Upload
+func New(useCase *uploadusecase.UploadUseCase) *UploadController {
+	return &UploadController{
+		useCase:   useCase,
+		prefixUrl: "/uploads",
+	}
+}
+
+// RegisterRoutes enregistre les routes du controller
+func (c *UploadController) RegisterRoutes(r httpgateway.Router) {
+	r.Handle("POST", c.prefixUrl, c.UploadFile)
+	r.Handle("GET", c.prefixUrl+"/url", c.GetFileUrl)
+	r.Handle("DELETE", c.prefixUrl, c.DeleteFile)
+}
+`, moduleName, moduleName)
+}
+
+func UploadFileContent(moduleName string) string {
+	return fmt.Sprintf(`package uploadcontroller
+
+import (
+	"fmt"
+	"%s/internal/application/gateway/httpgateway"
+)
+
+// UploadFile envoie un fichier dans le stockage
+// @Summary Envoyer un fichier
+// @Description Enregistre le fichier du champ file (10 Mo maximum) et retourne sa clé et une url temporaire de téléchargement
+// @Tags Upload
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Fichier à envoyer"
+// @Success 201 {object} UploadedFileResponse
+// @Failure 400 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /uploads [post]
+func (c *UploadController) UploadFile(ctx httpgateway.Context) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.BadRequest("Fichier manquant", err.Error())
+		return
+	}
+	if header.Size > maxFileSize {
+		ctx.UnprocessableEntity("Fichier trop volumineux", fmt.Sprintf("taille maximale: %%d octets", maxFileSize))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.BadRequest("Fichier illisible", err.Error())
+		return
+	}
+	defer file.Close()
+
+	uploaded, err := c.useCase.Upload(ctx.Request().Context(), header.Filename, header.Header.Get("Content-Type"), header.Size, file)
+	if err != nil {
+		ctx.Error("Erreur lors de l'envoi du fichier", err)
+		return
+	}
+
+	ctx.Created("Fichier envoyé", uploaded)
+}
+`, moduleName)
+}
+
+func GetFileUrlContent(moduleName string) string {
+	return fmt.Sprintf(`package uploadcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+)
+
+// GetFileUrl retourne une url temporaire de téléchargement d'un fichier
+// @Summary Url d'un fichier
+// @Description Retourne une url temporaire de téléchargement du fichier key
+// @Tags Upload
+// @Produce json
+// @Param key query string true "Clé du fichier (uploads/...)"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /uploads/url [get]
+func (c *UploadController) GetFileUrl(ctx httpgateway.Context) {
+	url, err := c.useCase.Url(ctx.Request().Context(), ctx.Query("key"))
+	if err != nil {
+		ctx.Error("Erreur lors de la récupération du fichier", err)
+		return
+	}
+
+	ctx.Success("Url du fichier", map[string]string{"url": url})
+}
+`, moduleName)
+}
+
+func DeleteFileContent(moduleName string) string {
+	return fmt.Sprintf(`package uploadcontroller
+
+import (
+	"%s/internal/application/gateway/httpgateway"
+)
+
+// DeleteFile supprime un fichier du stockage
+// @Summary Supprimer un fichier
+// @Description Supprime le fichier key, un fichier absent est ignoré
+// @Tags Upload
+// @Produce json
+// @Param key query string true "Clé du fichier (uploads/...)"
+// @Success 200 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /uploads [delete]
+func (c *UploadController) DeleteFile(ctx httpgateway.Context) {
+	err := c.useCase.Delete(ctx.Request().Context(), ctx.Query("key"))
+	if err != nil {
+		ctx.Error("Erreur lors de la suppression du fichier", err)
+		return
+	}
+
+	ctx.Success("Fichier supprimé", nil)
+}
+`, moduleName)
+}
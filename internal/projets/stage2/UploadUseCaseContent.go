@@ -0,0 +1,120 @@
+package stage2
+
+import "fmt"
+
+func UploadUseCaseContent(moduleName string) string {
+	return fmt.Sprintf(`package uploadusecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"%[1]s/internal/application/gateway/storagegateway"
+	"%[1]s/internal/domain/domainerror"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// prefix dossier des fichiers envoyés, les autres fichiers du bucket ne sont pas accessibles par ce use case
+const prefix = "uploads/"
+
+// ErrInvalidKey clé hors du dossier uploads/
+var ErrInvalidKey = domainerror.Validation("key", "clé de fichier invalide")
+
+var unsafeChars = regexp.MustCompile(`+"`[^a-zA-Z0-9._-]+`"+`)
+
+// UploadedFile fichier enregistré dans le stockage
+type UploadedFile struct {
+	Key         string `+"`json:\"key\"`"+`
+	Filename    string `+"`json:\"filename\"`"+`
+	ContentType string `+"`json:\"contentType\"`"+`
+	Size        int64  `+"`json:\"size\"`"+`
+	Url         string `+"`json:\"url\"`"+` // url temporaire de téléchargement
+}
+
+// UploadUseCase envoie des fichiers dans le stockage et signe leurs urls de téléchargement
+type UploadUseCase struct {
+	storage storagegateway.Storage
+	urlTtl  time.Duration
+}
+
+// NewUploadUseCase crée une nouvelle instance du use case, les urls de téléchargement sont valables urlTtl
+func NewUploadUseCase(storage storagegateway.Storage, urlTtl time.Duration) *UploadUseCase {
+	return &UploadUseCase{
+		storage: storage,
+		urlTtl:  urlTtl,
+	}
+}
+
+// Upload enregistre un fichier sous uploads/<année>/<mois>/<id>-<nom>
+func (uc *UploadUseCase) Upload(ctx context.Context, filename, contentType string, size int64, body io.Reader) (*UploadedFile, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%%s%%s/%%s-%%s", prefix, time.Now().Format("2006/01"), hex.EncodeToString(id), safeName(filename))
+
+	if err := uc.storage.Put(ctx, key, body, size, contentType); err != nil {
+		return nil, err
+	}
+
+	url, err := uc.storage.PresignGet(ctx, key, uc.urlTtl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadedFile{
+		Key:         key,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Url:         url,
+	}, nil
+}
+
+// Url url temporaire de téléchargement d'un fichier, storagegateway.ErrNotFound si le fichier est absent
+func (uc *UploadUseCase) Url(ctx context.Context, key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+
+	object, err := uc.storage.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	_ = object.Body.Close()
+
+	return uc.storage.PresignGet(ctx, key, uc.urlTtl)
+}
+
+// Delete supprime un fichier
+func (uc *UploadUseCase) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	return uc.storage.Delete(ctx, key)
+}
+
+// checkKey refuse les clés hors du dossier uploads/
+func checkKey(key string) error {
+	if !strings.HasPrefix(key, prefix) || path.Clean(key) != key {
+		return ErrInvalidKey
+	}
+	return nil
+}
+
+// safeName nom de fichier sans chemin ni caractères spéciaux
+func safeName(filename string) string {
+	name := unsafeChars.ReplaceAllString(path.Base(strings.ReplaceAll(filename, "\\", "/")), "_")
+	name = strings.Trim(name, "._")
+	if name == "" {
+		return "fichier"
+	}
+	return name
+}
+`, moduleName)
+}
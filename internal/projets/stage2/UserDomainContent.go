@@ -0,0 +1,134 @@
+package stage2
+
+import "fmt"
+
+func UserEntityContent(moduleName string) string {
+	return fmt.Sprintf(`package user
+
+import (
+	"fmt"
+	"%s/internal/domain/domainerror"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotFound utilisateur inexistant
+	ErrNotFound = domainerror.NotFound("utilisateur non trouvé")
+	// ErrEmailTaken email déjà utilisé par un autre utilisateur
+	ErrEmailTaken = domainerror.Conflict("email déjà utilisé")
+	// ErrInvalidRole rôle absent de RolePermissions
+	ErrInvalidRole = domainerror.Validation("roles", "rôle invalide")
+)
+
+const (
+	// RoleAdmin accès à toutes les routes protégées
+	RoleAdmin = "admin"
+	// RoleUser rôle donné à l'inscription
+	RoleUser = "user"
+)
+
+// RolePermissions permissions de chaque rôle, "*" donne accès à toutes les routes protégées.
+// Ajouter ici les rôles et permissions de l'api, ex: "editor": {"articles:write"}
+var RolePermissions = map[string][]string{
+	RoleAdmin: {"*"},
+	RoleUser:  {},
+}
+
+// User représente un utilisateur de l'api, le mot de passe n'est stocké que hashé
+type User struct {
+	ID           string    ` + "`bson:\"_id,omitempty\" json:\"id\"`" + `
+	Email        string    ` + "`bson:\"email\" json:\"email\"`" + `
+	PasswordHash string    ` + "`bson:\"passwordHash\" json:\"-\"`" + `
+	Roles        []string  ` + "`bson:\"roles\" json:\"roles\"`" + `
+	CreatedAt    time.Time ` + "`bson:\"createdAt\" json:\"createdAt\"`" + `
+}
+
+// NewUser crée une nouvelle instance de User avec le rôle RoleUser, l'email est normalisé en minuscules
+func NewUser(email, passwordHash string) *User {
+	return &User{
+		Email:        NormalizeEmail(email),
+		PasswordHash: passwordHash,
+		Roles:        []string{RoleUser},
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+// NormalizeEmail email sans espaces et en minuscules, utilisé pour l'unicité et la connexion
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidateRoles vérifie que chaque rôle existe dans RolePermissions, ErrInvalidRole sinon
+func ValidateRoles(roles []string) error {
+	for _, role := range roles {
+		if _, ok := RolePermissions[role]; !ok {
+			return fmt.Errorf("%%w: %%s", ErrInvalidRole, role)
+		}
+	}
+	return nil
+}
+
+// PermissionsOf permissions accordées par un ensemble de rôles, sans doublons
+func PermissionsOf(roles []string) []string {
+	var permissions []string
+	for _, role := range roles {
+		for _, permission := range RolePermissions[role] {
+			if !slices.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+`, moduleName)
+}
+
+func UserRepositoryInterfaceContent() string {
+	return `package user
+
+import "context"
+
+// UserRepository définit les opérations de persistance pour l'entité User
+type UserRepository interface {
+	// FindByID récupère un User par son ID, ErrNotFound s'il n'existe pas
+	FindByID(ctx context.Context, id string) (*User, error)
+
+	// FindByEmail récupère un User par son email, ErrNotFound s'il n'existe pas
+	FindByEmail(ctx context.Context, email string) (*User, error)
+
+	// Create crée un nouveau User, ErrEmailTaken si l'email est déjà utilisé
+	Create(ctx context.Context, user *User) error
+
+	// UpdateRoles remplace les rôles d'un User, ErrNotFound s'il n'existe pas
+	UpdateRoles(ctx context.Context, id string, roles []string) error
+}
+`
+}
+
+// UserSchemaContent collection MongoDB de User, appliquée au démarrage par mongoadapter.EnsureSchemas
+func UserSchemaContent(moduleName string) string {
+	return fmt.Sprintf(`package user
+
+import "%s/internal/domain/schema"
+
+// Schema collection users: email unique et validateur appliqués au démarrage de l'api
+var Schema = schema.Collection{
+	Name: "users",
+	Indexes: []schema.Index{
+		{Keys: []schema.Key{schema.Asc("email")}, Unique: true},
+	},
+	Validator: schema.JSONSchema{
+		"bsonType": "object",
+		"required": []string{"email", "passwordHash", "roles", "createdAt"},
+		"properties": map[string]any{
+			"email":        map[string]any{"bsonType": "string"},
+			"passwordHash": map[string]any{"bsonType": "string"},
+			"roles":        map[string]any{"bsonType": "array", "items": map[string]any{"bsonType": "string"}},
+			"createdAt":    map[string]any{"bsonType": "date"},
+		},
+	},
+}
+`, moduleName)
+}
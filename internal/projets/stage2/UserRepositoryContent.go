@@ -0,0 +1,164 @@
+package stage2
+
+import "fmt"
+
+func UserMongoRepositoryContent(moduleName string) string {
+	return fmt.Sprintf(`package userrepository
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"%[1]s/internal/domain/user"
+	"%[1]s/internal/infrastructure/adapter/mongoadapter"
+)
+
+// mongoUserRepository FindByID vient de mongoadapter.Repository
+type mongoUserRepository struct {
+	*mongoadapter.Repository[user.User]
+}
+
+// NewMongoUserRepository crée une nouvelle instance du repository MongoDB pour User,
+// l'index unique sur l'email est déclaré par user.Schema
+func NewMongoUserRepository(database *mongo.Database) user.UserRepository {
+	return &mongoUserRepository{
+		Repository: mongoadapter.NewRepository(database.Collection(user.Schema.Name), mongoadapter.Config[user.User]{
+			ID:            func(u *user.User) string { return u.ID },
+			NotFound:      user.ErrNotFound,
+			AlreadyExists: user.ErrEmailTaken,
+		}),
+	}
+}
+
+// FindByEmail récupère un User par son email
+func (r *mongoUserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.FindOne(ctx, bson.M{"email": user.NormalizeEmail(email)})
+}
+
+// Create crée un nouveau User
+func (r *mongoUserRepository) Create(ctx context.Context, userEntity *user.User) error {
+	// l'index unique renvoie aussi ErrEmailTaken, la recherche évite une erreur d'écriture dans le cas courant
+	if _, err := r.FindByEmail(ctx, userEntity.Email); err == nil {
+		return user.ErrEmailTaken
+	} else if !errors.Is(err, user.ErrNotFound) {
+		return err
+	}
+
+	return r.Repository.Create(ctx, userEntity)
+}
+
+// UpdateRoles remplace les rôles d'un User
+func (r *mongoUserRepository) UpdateRoles(ctx context.Context, id string, roles []string) error {
+	_, err := r.UpdateByID(ctx, id, bson.M{"roles": roles})
+	return err
+}
+`, moduleName)
+}
+
+// UserSqlRepositoryContent repository User pour PostgreSQL ou SQLite, table users de la migration 0002_create_users
+func UserSqlRepositoryContent(moduleName string, db Db) string {
+	return fmt.Sprintf(`package userrepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"%[1]s/internal/domain/user"
+	"strconv"
+	"strings"
+)
+
+// rolesSeparator séparateur des rôles dans la colonne roles
+const rolesSeparator = ","
+
+type sqlUserRepository struct {
+	db *sql.DB
+}
+
+// NewSqlUserRepository crée une nouvelle instance du repository %[2]s pour User
+func NewSqlUserRepository(db *sql.DB) user.UserRepository {
+	return &sqlUserRepository{
+		db: db,
+	}
+}
+
+// FindByID récupère un User par son ID
+func (r *sqlUserRepository) FindByID(ctx context.Context, id string) (*user.User, error) {
+	key, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, user.ErrNotFound
+	}
+	return r.findOne(ctx, "SELECT id, email, password_hash, roles, created_at FROM users WHERE id = %[3]s", key)
+}
+
+// FindByEmail récupère un User par son email
+func (r *sqlUserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	return r.findOne(ctx, "SELECT id, email, password_hash, roles, created_at FROM users WHERE email = %[3]s", user.NormalizeEmail(email))
+}
+
+// Create crée un nouveau User
+func (r *sqlUserRepository) Create(ctx context.Context, userEntity *user.User) error {
+	var key int64
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO users (email, password_hash, roles, created_at) VALUES (%[3]s, %[4]s, %[5]s, %[6]s) RETURNING id",
+		userEntity.Email, userEntity.PasswordHash, strings.Join(userEntity.Roles, rolesSeparator), userEntity.CreatedAt,
+	).Scan(&key)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return user.ErrEmailTaken
+		}
+		return fmt.Errorf("erreur lors de la création: %%w", err)
+	}
+
+	// Mettre à jour l'ID de l'entité avec celui généré par la base
+	userEntity.ID = strconv.FormatInt(key, 10)
+
+	return nil
+}
+
+// UpdateRoles remplace les rôles d'un User
+func (r *sqlUserRepository) UpdateRoles(ctx context.Context, id string, roles []string) error {
+	key, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return user.ErrNotFound
+	}
+
+	result, err := r.db.ExecContext(ctx, "UPDATE users SET roles = %[3]s WHERE id = %[4]s", strings.Join(roles, rolesSeparator), key)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la mise à jour des rôles: %%w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return user.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *sqlUserRepository) findOne(ctx context.Context, query string, args ...any) (*user.User, error) {
+	var key int64
+	var roles string
+	var result user.User
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&key, &result.Email, &result.PasswordHash, &roles, &result.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, user.ErrNotFound
+		}
+		return nil, fmt.Errorf("erreur lors de la récupération: %%w", err)
+	}
+	result.ID = strconv.FormatInt(key, 10)
+	if roles != "" {
+		result.Roles = strings.Split(roles, rolesSeparator)
+	}
+
+	return &result, nil
+}
+
+// isUniqueViolation contrainte UNIQUE non respectée (PostgreSQL: SQLSTATE 23505, SQLite: UNIQUE constraint failed)
+func isUniqueViolation(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "23505") || strings.Contains(message, "UNIQUE constraint failed")
+}
+`, moduleName, db.Label(), db.Placeholder(1), db.Placeholder(2), db.Placeholder(3), db.Placeholder(4))
+}
@@ -0,0 +1,160 @@
+package templates
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// downloadFile télécharge une url http(s) dans dst
+func downloadFile(url, dst string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return fmt.Errorf("téléchargement de %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("téléchargement de %s: statut %s", url, resp.Status)
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("création de %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		return fmt.Errorf("téléchargement de %s: %w", url, err)
+	}
+	return out.Close()
+}
+
+// extractTarGz extrait une archive .tar.gz dans dst
+// si l'archive contient un unique dossier racine (archives github), il est retiré
+func extractTarGz(archive, dst string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return fmt.Errorf("lecture de %s: %w", archive, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("décompression de %s: %w", archive, err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("lecture de l'archive %s: %w", archive, err)
+		}
+
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if name == "." || strings.HasPrefix(name, ".."+string(filepath.Separator)) || filepath.IsAbs(name) {
+			return fmt.Errorf("chemin invalide dans l'archive: %s", hdr.Name)
+		}
+		target := filepath.Join(dst, name)
+		// un lien de l'archive ne doit pas servir de chemin vers l'extérieur de dst
+		if err := checkNoSymlink(dst, name); err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return fmt.Errorf("création de %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return fmt.Errorf("création de %s: %w", filepath.Dir(target), err)
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+			if err != nil {
+				return fmt.Errorf("création de %s: %w", target, err)
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return fmt.Errorf("extraction de %s: %w", target, err)
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(hdr.Linkname) || !insideDir(dst, filepath.Join(filepath.Dir(target), hdr.Linkname)) {
+				return fmt.Errorf("lien invalide dans l'archive: %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return fmt.Errorf("création de %s: %w", filepath.Dir(target), err)
+			}
+			if err := os.Symlink(hdr.Linkname, target); err != nil {
+				return fmt.Errorf("création du lien %s: %w", target, err)
+			}
+		}
+	}
+
+	return stripSingleRoot(dst)
+}
+
+// insideDir indique si path est dst ou l'un de ses descendants
+func insideDir(dst, path string) bool {
+	rel, err := filepath.Rel(dst, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// checkNoSymlink refuse une entrée dont le chemin relatif name passe par un lien symbolique déjà extrait dans dst,
+// l'entrée elle-même comprise: écrire à travers le lien sortirait de dst
+func checkNoSymlink(dst, name string) error {
+	current := dst
+	for _, part := range strings.Split(name, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("lecture de %s: %w", current, err)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("chemin invalide dans l'archive, passe par le lien %s: %s", current, name)
+		}
+	}
+	return nil
+}
+
+// stripSingleRoot remonte le contenu d'un dossier racine unique d'un niveau
+func stripSingleRoot(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("lecture de %s: %w", dir, err)
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return nil
+	}
+
+	// renommage préalable au cas où le dossier racine contient un élément du même nom
+	root := filepath.Join(dir, ".starter-root")
+	if err := os.Rename(filepath.Join(dir, entries[0].Name()), root); err != nil {
+		return fmt.Errorf("déplacement de %s: %w", entries[0].Name(), err)
+	}
+	children, err := os.ReadDir(root)
+	if err != nil {
+		return fmt.Errorf("lecture de %s: %w", root, err)
+	}
+	for _, child := range children {
+		if err := os.Rename(filepath.Join(root, child.Name()), filepath.Join(dir, child.Name())); err != nil {
+			return fmt.Errorf("déplacement de %s: %w", child.Name(), err)
+		}
+	}
+	return os.Remove(root)
+}
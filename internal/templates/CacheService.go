@@ -0,0 +1,186 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// cacheMeta informations stockées à côté d'un template en cache
+type cacheMeta struct {
+	Source    string    `json:"source"`
+	Ref       string    `json:"ref"`
+	Commit    string    `json:"commit,omitempty"`
+	Sha256    string    `json:"sha256"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// CacheRoot retourne le dossier racine du cache des templates
+// $STARTER_CACHE_DIR si défini, sinon <cache utilisateur>/starter/templates
+func CacheRoot() (string, error) {
+	if p := os.Getenv("STARTER_CACHE_DIR"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("récupération du dossier de cache: %w", err)
+	}
+	return filepath.Join(dir, "starter", "templates"), nil
+}
+
+// cacheDir retourne le dossier de cache d'un template pour un ref
+func cacheDir(name, ref string) (string, error) {
+	root, err := CacheRoot()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, cacheKey(name), cacheKey(ref)), nil
+}
+
+// cacheKey transforme un nom ou un ref en nom de dossier (les points des versions sont conservés)
+func cacheKey(s string) string {
+	return unsafeKeyChars.ReplaceAllString(s, "_")
+}
+
+func readMeta(dir string) (*cacheMeta, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "meta.json"))
+	if err != nil {
+		return nil, err
+	}
+	var meta cacheMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("parsing du cache %s: %w", dir, err)
+	}
+	return &meta, nil
+}
+
+func writeMeta(dir string, meta cacheMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serialization du cache: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), data, 0o644); err != nil {
+		return fmt.Errorf("écriture du cache %s: %w", dir, err)
+	}
+	return nil
+}
+
+// TreeChecksum calcule un sha256 stable du contenu d'un dossier (.git ignoré):
+// chemin, permissions et contenu des fichiers, chemin et cible des liens symboliques
+func TreeChecksum(root string) (string, error) {
+	entries := map[string]os.FileInfo{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if info.Mode().IsRegular() || info.Mode()&os.ModeSymlink != 0 {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			entries[filepath.ToSlash(rel)] = info
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("parcours de %s: %w", root, err)
+	}
+	paths := make([]string, 0, len(entries))
+	for rel := range entries {
+		paths = append(paths, rel)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, rel := range paths {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		info := entries[rel]
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err := os.Readlink(path)
+			if err != nil {
+				return "", fmt.Errorf("lecture du lien %s: %w", rel, err)
+			}
+			fmt.Fprintf(h, "%s\x00link:%s\x00", rel, link)
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return "", fmt.Errorf("lecture de %s: %w", rel, err)
+		}
+		fmt.Fprintf(h, "%s\x00%o\x00", rel, info.Mode().Perm())
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", fmt.Errorf("lecture de %s: %w", rel, err)
+		}
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// CopyTree copie récursivement src dans dst en ignorant le dossier .git,
+// un lien symbolique absolu ou vers l'extérieur de dst est refusé comme dans une archive
+func CopyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.IsDir():
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0o755)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return fmt.Errorf("lecture du lien %s: %w", path, err)
+			}
+			if filepath.IsAbs(link) || !insideDir(dst, filepath.Join(filepath.Dir(target), link)) {
+				return fmt.Errorf("lien invalide dans le template: %s -> %s", rel, link)
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("lecture de %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("création de %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("copie de %s: %w", src, err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,72 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// remoteCommit résout le commit pointé par un ref (tag ou branche) sur le dépôt distant
+func remoteCommit(url, ref string) (string, error) {
+	out, err := gitOutput("", "ls-remote", url, "refs/tags/"+ref, "refs/tags/"+ref+"^{}", "refs/heads/"+ref)
+	if err != nil {
+		return "", fmt.Errorf("résolution de %s sur %s: %w", ref, url, err)
+	}
+
+	var tagCommit, peeledCommit, branchCommit string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[1] {
+		case "refs/tags/" + ref + "^{}":
+			peeledCommit = fields[0]
+		case "refs/tags/" + ref:
+			tagCommit = fields[0]
+		case "refs/heads/" + ref:
+			branchCommit = fields[0]
+		}
+	}
+
+	// un tag annoté pointe sur un objet tag, le commit est la version "peeled"
+	switch {
+	case peeledCommit != "":
+		return peeledCommit, nil
+	case tagCommit != "":
+		return tagCommit, nil
+	case branchCommit != "":
+		return branchCommit, nil
+	}
+	return "", fmt.Errorf("ref %s introuvable sur %s", ref, url)
+}
+
+// cloneRef clone un ref précis en profondeur 1 dans dst et retourne le commit obtenu
+func cloneRef(url, ref, dst string) (string, error) {
+	cmd := exec.Command("git", "clone", "--branch", ref, "--depth", "1", url, dst)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("clonage de %s (%s): %w", url, ref, err)
+	}
+
+	commit, err := gitOutput(dst, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("lecture du commit cloné: %w", err)
+	}
+	return strings.TrimSpace(commit), nil
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,46 @@
+package templates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ManifestFile nom du manifeste à la racine d'un template
+const ManifestFile = "starter.json"
+
+// Variable variable d'un template renseignée à l'initialisation
+type Variable struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Flag        string `json:"flag,omitempty"` // flag de la commande init correspondant
+}
+
+// Manifest description d'un template
+type Manifest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Variables   []Variable `json:"variables,omitempty"`
+}
+
+// ReadManifest lit le manifeste d'un template, nil si le template n'en a pas
+func ReadManifest(snap *Snapshot) (*Manifest, error) {
+	path := filepath.Join(snap.Dir, ManifestFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", ManifestFile, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing de %s: %w", ManifestFile, err)
+	}
+	return &manifest, nil
+}
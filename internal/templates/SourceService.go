@@ -0,0 +1,244 @@
+package templates
+
+import (
+	"fmt"
+	"github.com/nsevendev/starter/internal/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Kind type de source d'un template
+type Kind string
+
+const (
+	KindGit     Kind = "git"
+	KindTarball Kind = "tarball"
+	KindDir     Kind = "dir"
+)
+
+// FetchOptions options de récupération d'un template
+type FetchOptions struct {
+	Name    string      // nom du template (clé du cache)
+	Source  string      // url git, archive .tar.gz (url ou chemin) ou dossier local
+	Ref     string      // tag ou branche (git), clé de cache pour les autres sources
+	Offline bool        // n'utilise que le cache, aucun accès réseau
+	Pin     *config.Pin // nil = pas de vérification d'intégrité
+}
+
+// Snapshot template récupéré, prêt à être instancié
+type Snapshot struct {
+	Dir    string // dossier contenant les fichiers du template
+	Commit string // commit git (vide pour les autres sources)
+	Sha256 string // checksum du contenu (voir TreeChecksum)
+}
+
+// DetectKind déduit le type de source depuis sa forme
+func DetectKind(source string) Kind {
+	lower := strings.ToLower(source)
+	switch {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return KindTarball
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"),
+		strings.HasPrefix(lower, "git@"), strings.HasPrefix(lower, "ssh://"),
+		strings.HasPrefix(lower, "file://"), strings.HasSuffix(lower, ".git"):
+		return KindGit
+	}
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return KindDir
+	}
+	return KindGit
+}
+
+// Fetch récupère un template via le cache local et vérifie son intégrité
+func Fetch(opts FetchOptions) (*Snapshot, error) {
+	var (
+		snap *Snapshot
+		err  error
+	)
+
+	switch DetectKind(opts.Source) {
+	case KindDir:
+		snap, err = fetchDir(opts)
+	case KindTarball:
+		snap, err = fetchTarball(opts)
+	default:
+		snap, err = fetchGit(opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyPin(opts, snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Instantiate copie le template dans le dossier du projet
+func Instantiate(snap *Snapshot, dst string) error {
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return fmt.Errorf("création du dossier %s: %w", dst, err)
+	}
+	if err := CopyTree(snap.Dir, dst); err != nil {
+		return fmt.Errorf("copie du template dans %s: %w", dst, err)
+	}
+	return nil
+}
+
+func fetchDir(opts FetchOptions) (*Snapshot, error) {
+	dir, err := filepath.Abs(opts.Source)
+	if err != nil {
+		return nil, fmt.Errorf("chemin du template %s: %w", opts.Source, err)
+	}
+	sum, err := TreeChecksum(dir)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("  Template local: %s\n", dir)
+	return &Snapshot{Dir: dir, Sha256: sum}, nil
+}
+
+func fetchGit(opts FetchOptions) (*Snapshot, error) {
+	dir, err := cacheDir(opts.Name, opts.Ref)
+	if err != nil {
+		return nil, err
+	}
+	meta, metaErr := readMeta(dir)
+
+	if opts.Offline {
+		if metaErr != nil {
+			return nil, fmt.Errorf("mode offline: %s@%s absent du cache (%s)", opts.Name, opts.Ref, dir)
+		}
+		// le cache est indexé par nom et ref: une autre source (fork) ne doit pas réutiliser son contenu
+		if meta.Source != opts.Source {
+			return nil, fmt.Errorf("mode offline: %s@%s en cache provient de %s, pas de %s", opts.Name, opts.Ref, meta.Source, opts.Source)
+		}
+		fmt.Printf("  Template %s@%s depuis le cache (offline)\n", opts.Name, opts.Ref)
+		return snapshotFromCache(dir, meta)
+	}
+
+	commit, err := remoteCommit(opts.Source, opts.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	// le pin est vérifié avant tout téléchargement pour détecter un tag déplacé
+	if opts.Pin != nil && opts.Pin.Commit != "" && opts.Pin.Commit != commit {
+		return nil, fmt.Errorf("%s@%s pointe sur %s mais est épinglé sur %s (tag déplacé en amont ?)",
+			opts.Name, opts.Ref, commit, opts.Pin.Commit)
+	}
+
+	if metaErr == nil && meta.Commit == commit && meta.Source == opts.Source {
+		fmt.Printf("  Template %s@%s depuis le cache (%s)\n", opts.Name, opts.Ref, shortCommit(commit))
+		return snapshotFromCache(dir, meta)
+	}
+	if metaErr == nil && meta.Commit != commit {
+		fmt.Printf("  ⚠ %s@%s a changé en amont (%s -> %s), mise à jour du cache\n",
+			opts.Name, opts.Ref, shortCommit(meta.Commit), shortCommit(commit))
+	}
+
+	return refreshCache(dir, opts, func(tree string) (string, error) {
+		fmt.Printf("  Clonage du template %s@%s...\n", opts.Name, opts.Ref)
+		return cloneRef(opts.Source, opts.Ref, tree)
+	})
+}
+
+func fetchTarball(opts FetchOptions) (*Snapshot, error) {
+	dir, err := cacheDir(opts.Name, opts.Ref)
+	if err != nil {
+		return nil, err
+	}
+	remote := strings.HasPrefix(opts.Source, "http://") || strings.HasPrefix(opts.Source, "https://")
+
+	// une archive distante déjà en cache n'est pas retéléchargée
+	if meta, err := readMeta(dir); err == nil && meta.Source == opts.Source && remote {
+		fmt.Printf("  Template %s@%s depuis le cache\n", opts.Name, opts.Ref)
+		return snapshotFromCache(dir, meta)
+	}
+	if remote && opts.Offline {
+		return nil, fmt.Errorf("mode offline: %s@%s absent du cache (%s)", opts.Name, opts.Ref, dir)
+	}
+
+	return refreshCache(dir, opts, func(tree string) (string, error) {
+		archive := opts.Source
+		if remote {
+			fmt.Printf("  Téléchargement du template %s@%s...\n", opts.Name, opts.Ref)
+			archive = filepath.Join(filepath.Dir(tree), "template.tar.gz")
+			if err := downloadFile(opts.Source, archive); err != nil {
+				return "", err
+			}
+			defer os.Remove(archive)
+		}
+		return "", extractTarGz(archive, tree)
+	})
+}
+
+// refreshCache remplace le contenu en cache par celui produit par fill
+func refreshCache(dir string, opts FetchOptions, fill func(tree string) (string, error)) (*Snapshot, error) {
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("nettoyage du cache %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("création du cache %s: %w", dir, err)
+	}
+
+	tree := filepath.Join(dir, "tree")
+	commit, err := fill(tree)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	if err := os.RemoveAll(filepath.Join(tree, ".git")); err != nil {
+		return nil, fmt.Errorf("suppression du .git: %w", err)
+	}
+
+	sum, err := TreeChecksum(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := cacheMeta{Source: opts.Source, Ref: opts.Ref, Commit: commit, Sha256: sum, FetchedAt: time.Now()}
+	if err := writeMeta(dir, meta); err != nil {
+		return nil, err
+	}
+	return &Snapshot{Dir: tree, Commit: commit, Sha256: sum}, nil
+}
+
+// snapshotFromCache vérifie que le contenu en cache n'a pas été modifié depuis sa récupération
+func snapshotFromCache(dir string, meta *cacheMeta) (*Snapshot, error) {
+	tree := filepath.Join(dir, "tree")
+	sum, err := TreeChecksum(tree)
+	if err != nil {
+		return nil, err
+	}
+	if sum != meta.Sha256 {
+		return nil, fmt.Errorf("cache corrompu pour %s (checksum %s, attendu %s), supprimez le dossier %s",
+			meta.Ref, sum, meta.Sha256, dir)
+	}
+	return &Snapshot{Dir: tree, Commit: meta.Commit, Sha256: sum}, nil
+}
+
+func verifyPin(opts FetchOptions, snap *Snapshot) error {
+	if opts.Pin == nil {
+		return nil
+	}
+	if opts.Pin.Commit != "" && snap.Commit != "" && opts.Pin.Commit != snap.Commit {
+		return fmt.Errorf("%s@%s est au commit %s mais est épinglé sur %s",
+			opts.Name, opts.Ref, snap.Commit, opts.Pin.Commit)
+	}
+	if opts.Pin.Sha256 != "" && opts.Pin.Sha256 != snap.Sha256 {
+		return fmt.Errorf("checksum de %s@%s invalide: %s (épinglé: %s)",
+			opts.Name, opts.Ref, snap.Sha256, opts.Pin.Sha256)
+	}
+	fmt.Printf("    ✓ intégrité de %s@%s vérifiée\n", opts.Name, opts.Ref)
+	return nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
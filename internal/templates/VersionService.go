@@ -0,0 +1,169 @@
+package templates
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ListVersions liste les tags disponibles d'un template git, du plus récent au plus ancien
+// la liste est conservée en cache pour être consultable en mode offline
+func ListVersions(name, source string, offline bool) ([]string, error) {
+	if DetectKind(source) != KindGit {
+		return CachedRefs(name)
+	}
+
+	root, err := CacheRoot()
+	if err != nil {
+		return nil, err
+	}
+	versionsPath := filepath.Join(root, cacheKey(name), "versions.json")
+
+	if offline {
+		data, err := os.ReadFile(versionsPath)
+		if errors.Is(err, os.ErrNotExist) {
+			return CachedRefs(name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("lecture de %s: %w", versionsPath, err)
+		}
+		var versions []string
+		if err := json.Unmarshal(data, &versions); err != nil {
+			return nil, fmt.Errorf("parsing de %s: %w", versionsPath, err)
+		}
+		return versions, nil
+	}
+
+	out, err := gitOutput("", "ls-remote", "--tags", "--refs", source)
+	if err != nil {
+		return nil, fmt.Errorf("liste des tags de %s: %w", source, err)
+	}
+
+	var versions []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			versions = append(versions, strings.TrimPrefix(fields[1], "refs/tags/"))
+		}
+	}
+	sortVersions(versions)
+
+	if err := os.MkdirAll(filepath.Dir(versionsPath), 0o755); err == nil {
+		if data, err := json.Marshal(versions); err == nil {
+			_ = os.WriteFile(versionsPath, data, 0o644)
+		}
+	}
+
+	return versions, nil
+}
+
+// CachedRefs liste les refs d'un template présents dans le cache local
+func CachedRefs(name string) ([]string, error) {
+	root, err := CacheRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, cacheKey(name)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lecture du cache de %s: %w", name, err)
+	}
+
+	var refs []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if meta, err := readMeta(filepath.Join(root, cacheKey(name), entry.Name())); err == nil {
+			refs = append(refs, meta.Ref)
+		}
+	}
+	sortVersions(refs)
+	return refs, nil
+}
+
+// sortVersions trie des tags type v1.2.3 du plus récent au plus ancien
+func sortVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return compareVersions(versions[j], versions[i]) < 0
+	})
+}
+
+// compareVersions compare deux tags semver (v optionnel): -1 si a < b, 0 si égaux, 1 si a > b
+// les parties absentes valent 0 (1.2 == 1.2.0), une pré-version est antérieure à la version (1.2.0-rc1 < 1.2.0),
+// les métadonnées de build (+...) sont ignorées, les tags équivalents sont départagés par leur texte pour un ordre stable
+func compareVersions(a, b string) int {
+	if c := compareSemver(a, b); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func compareSemver(a, b string) int {
+	coreA, preA := splitVersion(a)
+	coreB, preB := splitVersion(b)
+
+	partsA, partsB := strings.Split(coreA, "."), strings.Split(coreB, ".")
+	for i := 0; i < max(len(partsA), len(partsB)); i++ {
+		partA, partB := "0", "0"
+		if i < len(partsA) {
+			partA = partsA[i]
+		}
+		if i < len(partsB) {
+			partB = partsB[i]
+		}
+		if c := compareIdentifier(partA, partB); c != 0 {
+			return c
+		}
+	}
+
+	// sans pré-version > avec pré-version
+	switch {
+	case preA == "" && preB == "":
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	}
+	idsA, idsB := strings.Split(preA, "."), strings.Split(preB, ".")
+	for i := 0; i < min(len(idsA), len(idsB)); i++ {
+		if c := compareIdentifier(idsA[i], idsB[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(idsA), len(idsB))
+}
+
+// splitVersion sépare le numéro de version et la pré-version d'un tag, sans le v ni les métadonnées de build
+func splitVersion(version string) (string, string) {
+	version = strings.TrimPrefix(version, "v")
+	version, _, _ = strings.Cut(version, "+")
+	core, pre, _ := strings.Cut(version, "-")
+	return core, pre
+}
+
+// compareIdentifier compare deux identifiants semver: numériquement s'ils sont numériques,
+// un identifiant numérique est antérieur à un identifiant texte, les textes sont comparés en ASCII
+func compareIdentifier(a, b string) int {
+	numA, errA := strconv.Atoi(a)
+	numB, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(numA, numB)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
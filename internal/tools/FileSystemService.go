@@ -3,7 +3,6 @@ package tools
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 )
@@ -64,57 +63,19 @@ func ReplaceInFile(path, old, new string) error {
 	return nil
 }
 
-// ReplaceInAllGoFiles parcourt récursivement un dossier et remplace du texte dans tous les fichiers .go
-func ReplaceInAllGoFiles(rootDir, old, new string) error {
-	var filesModified int
-
-	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Ignorer les dossiers
-		if info.IsDir() {
-			return nil
-		}
-
-		// Traiter uniquement les fichiers .go
-		if filepath.Ext(path) != ".go" {
-			return nil
-		}
-
-		// Lire le fichier
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("lecture du fichier %s: %w", path, err)
-		}
-
-		// Vérifier si le fichier contient le texte à remplacer
-		contentStr := string(content)
-		if !strings.Contains(contentStr, old) {
-			return nil
-		}
-
-		// Remplacer le texte
-		newContent := strings.ReplaceAll(contentStr, old, new)
-
-		// Écrire le fichier modifié
-		if err := os.WriteFile(path, []byte(newContent), 0o644); err != nil {
-			return fmt.Errorf("écriture du fichier %s: %w", path, err)
-		}
-
-		filesModified++
-		fmt.Printf("  ✓ %s modifié\n", path)
-		return nil
-	})
-
+// ReadEnvValue valeur d'une variable d'un fichier .env, ok vaut false si elle est absente
+func ReadEnvValue(path, key string) (value string, ok bool, err error) {
+	content, err := os.ReadFile(path)
 	if err != nil {
-		return err
+		return "", false, fmt.Errorf("lecture du fichier %s: %w", path, err)
 	}
-
-	if filesModified > 0 {
-		fmt.Printf("  Total: %d fichier(s) Go modifié(s)\n", filesModified)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		name, val, found := strings.Cut(line, "=")
+		if !found || strings.HasPrefix(line, "#") || strings.TrimSpace(strings.TrimPrefix(name, "export ")) != key {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(val), `"'`), true, nil
 	}
-
-	return nil
+	return "", false, nil
 }
@@ -0,0 +1,49 @@
+package tools
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var wordPattern = regexp.MustCompile(`[A-Z]+[a-z0-9]*|[a-z0-9]+`)
+
+// SplitWords découpe un nom en mots en minuscules, ex: "blogPost_v2" => ["blog", "post", "v2"]
+func SplitWords(s string) []string {
+	var words []string
+	for _, w := range wordPattern.FindAllString(s, -1) {
+		// un sigle suivi d'un mot ("HTTPServer") est découpé en "http" + "server"
+		if len(w) > 2 && unicode.IsUpper(rune(w[0])) && unicode.IsUpper(rune(w[1])) && unicode.IsLower(rune(w[len(w)-1])) {
+			i := strings.LastIndexFunc(w, unicode.IsUpper)
+			words = append(words, strings.ToLower(w[:i]), strings.ToLower(w[i:]))
+			continue
+		}
+		words = append(words, strings.ToLower(w))
+	}
+	return words
+}
+
+// PascalCase convertit des mots en identifiant Go exporté, ex: ["user", "id"] => "UserID"
+func PascalCase(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		if w == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// CamelCase convertit des mots en identifiant Go non exporté, ex: ["blog", "post"] => "blogPost"
+func CamelCase(words []string) string {
+	p := PascalCase(words)
+	if p == "" {
+		return ""
+	}
+	if strings.HasPrefix(p, "ID") {
+		return "id" + p[2:]
+	}
+	return strings.ToLower(p[:1]) + p[1:]
+}
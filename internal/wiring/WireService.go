@@ -0,0 +1,402 @@
+package wiring
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ControllersFunc fonction du main.go qui construit les controllers (repository -> use case -> controller)
+const ControllersFunc = "getControllers"
+
+// Registration éléments à câbler dans getControllers()
+type Registration struct {
+	Comment     string   // commentaire placé au-dessus des statements, ex: "BlogPost"
+	Imports     []string // chemins d'import
+	Statements  []string // ex: "blogPostRepo := blogpostrepository.NewMongoBlogPostRepository(mongoDatabase)"
+	Controllers []string // ajoutés à la liste retournée, ex: "blogpostcontroller.New(blogPostUseCase)"
+}
+
+// WireReport éléments réellement ajoutés, vide si tout était déjà câblé
+type WireReport struct {
+	Imports     []string
+	Statements  []string
+	Controllers []string
+}
+
+// Empty indique que le main.go n'a pas été modifié
+func (r *WireReport) Empty() bool {
+	return len(r.Imports) == 0 && len(r.Statements) == 0 && len(r.Controllers) == 0
+}
+
+// edit remplace src[pos:end] par value (simple insertion si end vaut 0)
+type edit struct {
+	pos   int
+	end   int
+	order int
+	value string
+}
+
+// WireControllers câble une registration dans getControllers() du main.go:
+// - ajoute les imports manquants dans le bloc d'import (ordre alphabétique)
+// - ajoute les statements absents avant le return
+// - ajoute les controllers absents à la fin de la liste retournée
+// le fichier est modifié par insertions (le reste est conservé), reformaté puis vérifié
+// relancer avec la même registration ne modifie rien
+func WireControllers(mainPath string, reg Registration) (*WireReport, error) {
+	src, err := os.ReadFile(mainPath)
+	if err != nil {
+		return nil, fmt.Errorf("lecture de %s: %w", mainPath, err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, mainPath, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parsing de %s: %w", mainPath, err)
+	}
+	offset := func(p token.Pos) int { return fset.Position(p).Offset }
+
+	fn := findFunc(file, ControllersFunc)
+	if fn == nil || fn.Body == nil {
+		return nil, fmt.Errorf("fonction %s() absente de %s", ControllersFunc, mainPath)
+	}
+	ret, list := findReturnedList(fn)
+	if ret == nil {
+		return nil, fmt.Errorf("%s() de %s doit se terminer par return []httpgateway.Routable{...}", ControllersFunc, mainPath)
+	}
+
+	report := &WireReport{}
+	var edits []edit
+
+	// imports
+	importEdits, err := importEdits(file, offset, src, reg.Imports, report)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", mainPath, err)
+	}
+	edits = append(edits, importEdits...)
+
+	// statements, un statement dont les variables sont déjà déclarées est considéré comme câblé,
+	// un statement sans variable (ex: ensureSchemas(...)) l'est si le même appel est déjà présent
+	declared := declaredNames(fn)
+	calls := exprStatements(fset, fn)
+	var statements []string
+	for _, stmt := range reg.Statements {
+		stmtFset, stmtFn, err := parseStatement(stmt)
+		if err != nil {
+			return nil, err
+		}
+		names := assignedNames(stmtFn)
+		if len(names) > 0 && allDeclared(names, declared) {
+			continue
+		}
+		if len(names) == 0 && len(stmtFn.Body.List) == 1 {
+			if call, ok := exprSource(stmtFset, stmtFn.Body.List[0]); ok {
+				if calls[call] {
+					continue
+				}
+				calls[call] = true
+			}
+		}
+		statements = append(statements, stmt)
+		report.Statements = append(report.Statements, stmt)
+	}
+	if len(statements) > 0 {
+		pos := lineStart(src, offset(leadingCommentPos(file, fset, ret)))
+		var b strings.Builder
+		// ligne vide de séparation, sauf en début de fonction
+		if pos < 2 || src[pos-2] != '{' {
+			b.WriteString("\n")
+		}
+		if reg.Comment != "" {
+			b.WriteString("// " + reg.Comment + "\n")
+		}
+		for _, stmt := range statements {
+			b.WriteString(stmt + "\n")
+		}
+		b.WriteString("\n")
+		edits = append(edits, edit{pos: pos, value: b.String()})
+	}
+
+	// controllers
+	existing := map[string]bool{}
+	for _, elt := range list.Elts {
+		existing[normalize(string(src[offset(elt.Pos()):offset(elt.End())]))] = true
+	}
+	var controllers strings.Builder
+	for _, ctrl := range reg.Controllers {
+		if existing[normalize(ctrl)] {
+			continue
+		}
+		existing[normalize(ctrl)] = true
+		controllers.WriteString(ctrl + ",\n")
+		report.Controllers = append(report.Controllers, ctrl)
+	}
+	if controllers.Len() > 0 {
+		rbrace := offset(list.Rbrace)
+		value := controllers.String()
+		// liste sur une seule ligne ({} ou {a, b}): les controllers passent à la ligne
+		if len(list.Elts) == 0 || fset.Position(list.Elts[len(list.Elts)-1].End()).Line == fset.Position(list.Rbrace).Line {
+			if len(list.Elts) > 0 {
+				value = ",\n" + value
+				edits = append(edits, edit{pos: offset(list.Lbrace) + 1, value: "\n"})
+			} else {
+				value = "\n" + value
+			}
+			edits = append(edits, edit{pos: trimTrailingComma(src, rbrace), value: value})
+		} else {
+			edits = append(edits, edit{pos: lineStart(src, rbrace), value: value})
+		}
+	}
+
+	if report.Empty() {
+		return report, nil
+	}
+
+	// application de la fin vers le début pour garder les offsets valides
+	// à position égale, la dernière insertion est appliquée en premier pour conserver leur ordre
+	for i := range edits {
+		edits[i].order = i
+	}
+	sort.Slice(edits, func(i, j int) bool {
+		if edits[i].pos != edits[j].pos {
+			return edits[i].pos > edits[j].pos
+		}
+		return edits[i].order > edits[j].order
+	})
+	out := src
+	for _, e := range edits {
+		end := e.pos
+		if e.end > e.pos {
+			end = e.end
+		}
+		out = append(out[:e.pos:e.pos], append([]byte(e.value), out[end:]...)...)
+	}
+
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, fmt.Errorf("main.go invalide après câblage %s: %w", mainPath, err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), mainPath, formatted, parser.ParseComments); err != nil {
+		return nil, fmt.Errorf("main.go invalide après câblage %s: %w", mainPath, err)
+	}
+
+	if err := os.WriteFile(mainPath, formatted, 0o644); err != nil {
+		return nil, fmt.Errorf("écriture de %s: %w", mainPath, err)
+	}
+	return report, nil
+}
+
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// findReturnedList retourne le dernier return de la fonction et la liste littérale retournée
+func findReturnedList(fn *ast.FuncDecl) (*ast.ReturnStmt, *ast.CompositeLit) {
+	if len(fn.Body.List) == 0 {
+		return nil, nil
+	}
+	ret, ok := fn.Body.List[len(fn.Body.List)-1].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil, nil
+	}
+	list, ok := ret.Results[0].(*ast.CompositeLit)
+	if !ok {
+		return nil, nil
+	}
+	return ret, list
+}
+
+// leadingCommentPos position du commentaire collé au-dessus du return (ex: "// Retourner les controllers")
+func leadingCommentPos(file *ast.File, fset *token.FileSet, ret *ast.ReturnStmt) token.Pos {
+	retLine := fset.Position(ret.Pos()).Line
+	for _, group := range file.Comments {
+		if fset.Position(group.End()).Line == retLine-1 {
+			return group.Pos()
+		}
+	}
+	return ret.Pos()
+}
+
+func importEdits(file *ast.File, offset func(token.Pos) int, src []byte, imports []string, report *WireReport) ([]edit, error) {
+	present := map[string]bool{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, fmt.Errorf("import invalide %s", spec.Path.Value)
+		}
+		present[path] = true
+	}
+
+	var missing []string
+	for _, path := range imports {
+		if !present[path] {
+			present[path] = true
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	sort.Strings(missing)
+	report.Imports = missing
+
+	var block *ast.GenDecl
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			block = gen
+			break
+		}
+	}
+
+	// pas de bloc d'import: un nouveau bloc est ajouté après le package
+	if block == nil {
+		return []edit{{pos: offset(file.Name.End()), value: "\n\nimport (\n" + quoteAll(missing) + ")"}}, nil
+	}
+
+	// import sur une ligne (import "fmt"): transformé en bloc
+	if !block.Lparen.IsValid() {
+		spec := block.Specs[0].(*ast.ImportSpec)
+		existing := string(src[offset(spec.Pos()):offset(spec.End())])
+		specs := append([]string{existing}, quoteAll(missing))
+		return []edit{{pos: offset(block.Pos()), end: offset(block.End()), value: "import (\n" + strings.Join(specs, "\n") + ")"}}, nil
+	}
+
+	// insertion de chaque import avant le premier import qui le suit dans l'ordre alphabétique
+	var edits []edit
+	for _, path := range missing {
+		pos := lineStart(src, offset(block.Rparen))
+		for _, spec := range block.Specs {
+			imp := spec.(*ast.ImportSpec)
+			existing, _ := strconv.Unquote(imp.Path.Value)
+			if existing > path {
+				pos = lineStart(src, offset(imp.Pos()))
+				break
+			}
+		}
+		edits = append(edits, edit{pos: pos, value: strconv.Quote(path) + "\n"})
+	}
+	return edits, nil
+}
+
+func quoteAll(paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		b.WriteString(strconv.Quote(path) + "\n")
+	}
+	return b.String()
+}
+
+// declaredNames variables déclarées dans la fonction (:= et var)
+func declaredNames(fn *ast.FuncDecl) map[string]bool {
+	names := map[string]bool{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch s := n.(type) {
+		case *ast.AssignStmt:
+			if s.Tok == token.DEFINE {
+				for _, lhs := range s.Lhs {
+					if id, ok := lhs.(*ast.Ident); ok {
+						names[id.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, id := range s.Names {
+				names[id.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// parseStatement analyse un statement seul, placé dans le corps d'une fonction
+func parseStatement(stmt string) (*token.FileSet, *ast.FuncDecl, error) {
+	wrapped := "package p\nfunc _() {\n" + stmt + "\n}\n"
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", wrapped, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("statement invalide %q: %w", stmt, err)
+	}
+	return fset, file.Decls[0].(*ast.FuncDecl), nil
+}
+
+// assignedNames variables déclarées par le statement analysé par parseStatement
+func assignedNames(fn *ast.FuncDecl) []string {
+	var names []string
+	for name := range declaredNames(fn) {
+		if name != "_" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// exprStatements sources des statements sans affectation de la fonction (appels), normalisées
+func exprStatements(fset *token.FileSet, fn *ast.FuncDecl) map[string]bool {
+	calls := map[string]bool{}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if stmt, ok := n.(ast.Stmt); ok {
+			if call, ok := exprSource(fset, stmt); ok {
+				calls[call] = true
+			}
+		}
+		return true
+	})
+	return calls
+}
+
+// exprSource source normalisée d'un statement sans affectation, false pour les autres statements
+func exprSource(fset *token.FileSet, stmt ast.Stmt) (string, bool) {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return "", false
+	}
+	var b bytes.Buffer
+	if err := printer.Fprint(&b, fset, expr); err != nil {
+		return "", false
+	}
+	return normalize(b.String()), true
+}
+
+func allDeclared(names []string, declared map[string]bool) bool {
+	for _, name := range names {
+		if !declared[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize supprime les espaces pour comparer deux expressions
+func normalize(expr string) string {
+	return strings.Join(strings.Fields(expr), "")
+}
+
+func lineStart(src []byte, pos int) int {
+	return bytes.LastIndexByte(src[:pos], '\n') + 1
+}
+
+// trimTrailingComma position juste après le dernier élément d'une liste sur une ligne (avant une éventuelle virgule finale)
+func trimTrailingComma(src []byte, rbrace int) int {
+	pos := rbrace
+	for pos > 0 && (src[pos-1] == ' ' || src[pos-1] == '\t') {
+		pos--
+	}
+	if pos > 0 && src[pos-1] == ',' {
+		pos--
+	}
+	return pos
+}